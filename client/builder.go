@@ -35,6 +35,38 @@ func WithHTTPClient(httpClient *http.Client) func(*sdkcore.CoreClient) {
 	}
 }
 
+// Validate requests against the API schema constraints before they are sent,
+// failing requests return a sdkcore.ValidationError
+func WithRequestValidation() func(*sdkcore.CoreClient) {
+	return func(c *sdkcore.CoreClient) {
+		c.ValidateRequests = true
+	}
+}
+
+// Choose how enum values unknown to the SDK are decoded from responses:
+// sdkcore.EnumPolicyLenient (default) keeps them, sdkcore.EnumPolicyStrict fails decoding
+func WithEnumPolicy(policy sdkcore.EnumPolicy) func(*sdkcore.CoreClient) {
+	return func(c *sdkcore.CoreClient) {
+		c.EnumPolicy = policy
+	}
+}
+
+// Fail decoding responses that hold properties unknown to the SDK with a
+// sdkcore.UnknownFieldError, by default they are kept in the models' AdditionalProperties
+func WithStrictDecoding() func(*sdkcore.CoreClient) {
+	return func(c *sdkcore.CoreClient) {
+		c.StrictDecoding = true
+	}
+}
+
+// Register a codec encoding & decoding bodies of the media type, e.g. to use a
+// faster JSON library, replacing the built-in codec for the media type if any
+func WithCodec(mediaType string, codec sdkcore.Codec) func(*sdkcore.CoreClient) {
+	return func(c *sdkcore.CoreClient) {
+		c.RegisterCodec(mediaType, codec)
+	}
+}
+
 func WithApiKey(apiKey string) func(*sdkcore.CoreClient) {
 	return func(c *sdkcore.CoreClient) {
 		c.Auth["api_key"] = sdkcore.NewAuthKeyHeader("api_key", apiKey)
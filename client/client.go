@@ -4,12 +4,14 @@ import (
 	sdkcore "pets_go/core"
 	pet "pets_go/resources/pet"
 	store "pets_go/resources/store"
+	user "pets_go/resources/user"
 )
 
 type Client struct {
 	coreClient *sdkcore.CoreClient
 	Pet        *pet.Client
 	Store      *store.Client
+	User       *user.Client
 }
 
 // Instantiate a new API client
@@ -24,6 +26,7 @@ func NewClient(builders ...func(*sdkcore.CoreClient)) *Client {
 		coreClient: coreClient,
 		Pet:        pet.NewClient(coreClient),
 		Store:      store.NewClient(coreClient),
+		User:       user.NewClient(coreClient),
 	}
 
 	return &client
@@ -0,0 +1,210 @@
+package client
+
+import (
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	http "net/http"
+	url "net/url"
+	sdkcore "pets_go/core"
+	sort "sort"
+	strings "strings"
+)
+
+// Request describes a call to an endpoint the SDK has no operation for yet, sent with
+// Client.Do the way generated operations are: resolved against the base URL, authenticated,
+// modified, mapped to sdkcore.ApiError on failure and decoded with the client's codecs
+type Request struct {
+	// HTTP method, GET if empty
+	Method string
+	// Path relative to the base URL, `{name}` placeholders are replaced by the escaped
+	// PathParams, e.g. `/pet/{petId}`
+	Path string
+	// Values of the path placeholders, formatted like the operations' path parameters
+	PathParams map[string]interface{}
+	// Query parameters, form style. Undefined nullable values are omitted
+	Query map[string]interface{}
+	// How list query parameters are written, exploded by default
+	QueryArrayEncoding sdkcore.ArrayEncoding
+	// Encoded with the client's codec for ContentType, no body if nil
+	Body interface{}
+	// Media type the body is sent as, ContentTypeJSON if empty
+	ContentType string
+	// Media types accepted in the response, JSON & XML if empty
+	Accept []string
+	// Auth schemes applied to the request, e.g. "api_key"
+	Auth []string
+	// Further headers to send
+	Headers http.Header
+}
+
+// Builds the http.Request described by the request, authenticated and with the client &
+// request level modifiers applied, see Do to send it
+func (c *Client) Request(request Request, reqModifiers ...RequestModifier) (*http.Request, error) {
+	// URL formatting
+	path, err := expandPath(request.Path, request.PathParams)
+	if err != nil {
+		return nil, err
+	}
+	targetUrl, err := c.coreClient.BuildURL(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Query params
+	if len(request.Query) > 0 {
+		params := targetUrl.Query()
+		for name, value := range request.Query {
+			sdkcore.AddQueryParam(params, name, value, "form", request.QueryArrayEncoding.Explode())
+		}
+		targetUrl.RawQuery = sdkcore.CanonicalQueryString(params)
+	}
+
+	// Prep body
+	var reqBodyBuf io.Reader
+	contentType := ""
+	if request.Body != nil {
+		contentType = request.ContentType
+		if contentType == "" {
+			contentType = sdkcore.ContentTypeJSON
+		}
+		reqBodyBuf, contentType, err = c.coreClient.EncodeBody(request.Body, contentType, sdkcore.EncodeOptions{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Init request
+	method := request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequest(method, targetUrl.String(), reqBodyBuf)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+	accept := request.Accept
+	if len(accept) == 0 {
+		accept = []string{sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML}
+	}
+	req.Header.Add("Accept", c.coreClient.Accept(accept...))
+	if contentType != "" {
+		req.Header.Add("Content-Type", contentType)
+	}
+	for name, values := range request.Headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	// Add auth
+	err = c.coreClient.AddAuth(req, request.Auth...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// Sends the request, decoding the response body into out according to its content type.
+// With a nil out the body is left unread for the caller to consume & close, otherwise it
+// is closed and an empty body leaves out unchanged. Status codes of 300 and above fail
+// with an sdkcore.ApiError
+//
+//	import sdk "pets_go/client"
+//
+//	client := sdk.NewClient(sdk.WithApiKey(os.Getenv("API_KEY")))
+//	var pet types.Pet
+//	_, err := client.Do(sdk.Request{
+//		Method:     "GET",
+//		Path:       "/pet/{petId}",
+//		PathParams: map[string]interface{}{"petId": 10},
+//		Auth:       []string{"api_key"},
+//	}, &pet)
+func (c *Client) Do(request Request, out interface{}, reqModifiers ...RequestModifier) (http.Response, error) {
+	req, err := c.Request(request, reqModifiers...)
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return http.Response{}, sdkcore.NewApiError(*req, *resp)
+	}
+	if out == nil {
+		return *resp, nil
+	}
+
+	// Handle response
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return http.Response{}, err
+	}
+	if len(body) == 0 {
+		return *resp, nil
+	}
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, out)
+	if err != nil {
+		return http.Response{}, err
+	}
+	return *resp, nil
+}
+
+// Replaces the `{name}` placeholders of the path template, failing on missing or unused
+// parameters so a misspelled name is not sent as is
+func expandPath(template string, params map[string]interface{}) (string, error) {
+	if template == "" {
+		return "", errors.New("request path is required")
+	}
+
+	var path strings.Builder
+	used := map[string]bool{}
+	rest := template
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			path.WriteString(rest)
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unclosed parameter in path %q", template)
+		}
+		name := rest[start+1 : start+end]
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("path parameter %q of %q is missing", name, template)
+		}
+		used[name] = true
+		path.WriteString(rest[:start])
+		path.WriteString(url.PathEscape(sdkcore.FmtStringParam(value)))
+		rest = rest[start+end+1:]
+	}
+
+	unused := []string{}
+	for name := range params {
+		if !used[name] {
+			unused = append(unused, name)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return "", fmt.Errorf("path parameters %s are not in path %q", strings.Join(unused, ", "), template)
+	}
+	return path.String(), nil
+}
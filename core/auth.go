@@ -80,7 +80,7 @@ func (a *AuthKey) Apply(req *http.Request) error {
 	case "query":
 		queryParams := req.URL.Query()
 		queryParams.Add(a.name, a.value)
-		req.URL.RawQuery = queryParams.Encode()
+		req.URL.RawQuery = CanonicalQueryString(queryParams)
 	case "cookie":
 		authCookie := http.Cookie{Name: a.name, Value: a.value}
 		req.AddCookie(&authCookie)
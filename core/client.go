@@ -1,6 +1,7 @@
 package core
 
 import (
+	context "context"
 	http "net/http"
 	url "net/url"
 	strings "strings"
@@ -11,9 +12,36 @@ type CoreClient struct {
 	HttpClient *http.Client
 	Auth       map[string]AuthProvider
 	Modifiers  []RequestModifier
+	// Validate requests against the API schema before they are sent
+	ValidateRequests bool
+	// How enum values unknown to the SDK are decoded, lenient by default
+	EnumPolicy EnumPolicy
+	// Fail decoding responses holding properties unknown to the SDK instead of
+	// capturing them in the models' AdditionalProperties
+	StrictDecoding bool
+	// Request & response body codecs by media type, see RegisterCodec
+	Codecs map[string]Codec
 }
 type RequestModifier = func(req *http.Request) error
 
+// Binds the request to a context, cancelling the context aborts the request
+// including any body still being sent
+func WithContext(ctx context.Context) RequestModifier {
+	return func(req *http.Request) error {
+		*req = *req.WithContext(ctx)
+		return nil
+	}
+}
+
+// Sets the Accept header of the request, overriding the media types the operation
+// prefers, e.g. to receive XML where JSON is preferred
+func WithAccept(mediaTypes ...string) RequestModifier {
+	return func(req *http.Request) error {
+		req.Header.Set("Accept", strings.Join(mediaTypes, ", "))
+		return nil
+	}
+}
+
 const defaultServiceName = "__default_service__"
 
 func DefaultBaseURL(baseURL string) map[string]string {
@@ -25,6 +53,7 @@ func NewCoreClient(baseURL map[string]string) *CoreClient {
 		BaseURL:    baseURL,
 		HttpClient: http.DefaultClient,
 		Auth:       map[string]AuthProvider{},
+		Codecs:     DefaultCodecs(),
 	}
 	return &client
 }
@@ -0,0 +1,294 @@
+package core
+
+import (
+	bytes "bytes"
+	json "encoding/json"
+	xml "encoding/xml"
+	fmt "fmt"
+	io "io"
+	fs "io/fs"
+	mime "mime"
+	multipart "mime/multipart"
+	url "net/url"
+	reflect "reflect"
+	sort "sort"
+	strings "strings"
+)
+
+// Media types of the built-in codecs
+const (
+	ContentTypeJSON           = "application/json"
+	ContentTypeXML            = "application/xml"
+	ContentTypeFormUrlEncoded = "application/x-www-form-urlencoded"
+	ContentTypeMultipart      = "multipart/form-data"
+	ContentTypeOctetStream    = "application/octet-stream"
+)
+
+// Codec encodes request bodies and decodes response bodies of a media type. Codecs are
+// registered on the CoreClient by media type, see RegisterCodec
+type Codec interface {
+	// Encodes the body, returning it with the value of its Content-Type header
+	Encode(body interface{}, options EncodeOptions) (io.Reader, string, error)
+	// Decodes a body into v, contentType is the full Content-Type header value
+	// including parameters such as charset or boundary
+	Decode(data []byte, contentType string, v interface{}) error
+}
+
+// Per operation encoding options
+type EncodeOptions struct {
+	// Styles & explode flags of form fields, see FormUrlEncodedBody
+	FormStyle   map[string]string
+	FormExplode map[string]bool
+}
+
+// Returns a new registry holding the built-in codecs
+func DefaultCodecs() map[string]Codec {
+	return map[string]Codec{
+		ContentTypeJSON:           JSONCodec{},
+		ContentTypeXML:            XMLCodec{},
+		ContentTypeFormUrlEncoded: FormUrlEncodedCodec{},
+		ContentTypeMultipart:      MultipartCodec{},
+		ContentTypeOctetStream:    OctetStreamCodec{},
+	}
+}
+
+// Registers the codec for the media type, replacing any codec registered for it. Codecs
+// must be registered before the client is used concurrently
+func (c *CoreClient) RegisterCodec(mediaType string, codec Codec) {
+	if c.Codecs == nil {
+		c.Codecs = DefaultCodecs()
+	}
+	c.Codecs[strings.ToLower(mediaType)] = codec
+}
+
+// Returns the codec for a Content-Type header value. Parameters are ignored and media
+// types with a `+json` or `+xml` suffix fall back on the JSON & XML codecs
+func (c *CoreClient) Codec(contentType string) (Codec, bool) {
+	codecs := c.Codecs
+	if codecs == nil {
+		codecs = DefaultCodecs()
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	if codec, ok := codecs[mediaType]; ok {
+		return codec, true
+	}
+	if strings.HasSuffix(mediaType, "+json") {
+		codec, ok := codecs[ContentTypeJSON]
+		return codec, ok
+	}
+	if strings.HasSuffix(mediaType, "+xml") || mediaType == "text/xml" {
+		codec, ok := codecs[ContentTypeXML]
+		return codec, ok
+	}
+	return nil, false
+}
+
+// Encodes a request body with the codec registered for the content type, returning
+// the body with the value of its Content-Type header
+func (c *CoreClient) EncodeBody(body interface{}, contentType string, options EncodeOptions) (io.Reader, string, error) {
+	codec, ok := c.Codec(contentType)
+	if !ok {
+		return nil, "", fmt.Errorf("no codec registered for request body content type %q", contentType)
+	}
+	return codec.Encode(body, options)
+}
+
+// Builds an Accept header value from the media types an operation responds with, in
+// order of preference, leaving out the ones no codec is registered for
+func (c *CoreClient) Accept(mediaTypes ...string) string {
+	accepted := []string{}
+	for _, mediaType := range mediaTypes {
+		if _, ok := c.Codec(mediaType); ok {
+			accepted = append(accepted, mediaType)
+		}
+	}
+	return strings.Join(accepted, ", ")
+}
+
+// JSONCodec encodes & decodes application/json with encoding/json
+type JSONCodec struct{}
+
+func (JSONCodec) Encode(body interface{}, options EncodeOptions) (io.Reader, string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, "", err
+	}
+	return bytes.NewReader(data), ContentTypeJSON, nil
+}
+
+func (JSONCodec) Decode(data []byte, contentType string, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// XMLCodec encodes & decodes application/xml with encoding/xml, a list is decoded
+// from the children of the root element
+type XMLCodec struct{}
+
+func (XMLCodec) Encode(body interface{}, options EncodeOptions) (io.Reader, string, error) {
+	data, err := xml.Marshal(body)
+	if err != nil {
+		return nil, "", err
+	}
+	return bytes.NewReader(data), ContentTypeXML, nil
+}
+
+func (XMLCodec) Decode(data []byte, contentType string, v interface{}) error {
+	return decodeXML(data, v)
+}
+
+// FormUrlEncodedCodec encodes structs & maps as application/x-www-form-urlencoded, see
+// FormUrlEncodedBody. Bodies are decoded into *url.Values only
+type FormUrlEncodedCodec struct{}
+
+func (FormUrlEncodedCodec) Encode(body interface{}, options EncodeOptions) (io.Reader, string, error) {
+	reader, err := FormUrlEncodedBody(body, options.FormStyle, options.FormExplode)
+	if err != nil {
+		return nil, "", err
+	}
+	return reader, ContentTypeFormUrlEncoded, nil
+}
+
+func (FormUrlEncodedCodec) Decode(data []byte, contentType string, v interface{}) error {
+	values, ok := v.(*url.Values)
+	if !ok {
+		return fmt.Errorf("%s bodies can only be decoded into *url.Values, received %T", ContentTypeFormUrlEncoded, v)
+	}
+	parsed, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+	*values = parsed
+	return nil
+}
+
+// MultipartCodec streams []FormDataField, structs & maps as multipart/form-data, see
+// AddToFormDataWriter for the supported values. Bodies are decoded into *multipart.Form only
+type MultipartCodec struct{}
+
+func (MultipartCodec) Encode(body interface{}, options EncodeOptions) (io.Reader, string, error) {
+	fields, err := formDataFields(body)
+	if err != nil {
+		return nil, "", err
+	}
+	reader, contentType := MultipartBody(fields...)
+	return reader, contentType, nil
+}
+
+func (MultipartCodec) Decode(data []byte, contentType string, v interface{}) error {
+	form, ok := v.(*multipart.Form)
+	if !ok {
+		return fmt.Errorf("%s bodies can only be decoded into *multipart.Form, received %T", ContentTypeMultipart, v)
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return err
+	}
+	parsed, err := multipart.NewReader(bytes.NewReader(data), params["boundary"]).ReadForm(int64(len(data)))
+	if err != nil {
+		return err
+	}
+	*form = *parsed
+	return nil
+}
+
+// Lists the fields of a multipart body in order: structs by declaration with their JSON
+// names, maps by sorted key
+func formDataFields(body interface{}) ([]FormDataField, error) {
+	if fields, ok := body.([]FormDataField); ok {
+		return fields, nil
+	}
+
+	val := reflect.ValueOf(body)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	fields := []FormDataField{}
+	switch val.Kind() {
+	case reflect.Map:
+		keys := val.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			fields = append(fields, FormDataField{Name: fmt.Sprint(key.Interface()), Value: val.MapIndex(key).Interface()})
+		}
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			field := val.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			// undefined nullables are zero as well
+			if strings.Contains(opts, "omitempty") && val.Field(i).IsZero() {
+				continue
+			}
+			fields = append(fields, FormDataField{Name: name, Value: val.Field(i).Interface()})
+		}
+	default:
+		return nil, fmt.Errorf("%s data must be a map or a struct at the top level", ContentTypeMultipart)
+	}
+
+	return fields, nil
+}
+
+// OctetStreamCodec sends []byte, string, io.Reader & File bodies as is. Bodies are
+// decoded into *[]byte, *string or an io.Writer
+type OctetStreamCodec struct{}
+
+func (OctetStreamCodec) Encode(body interface{}, options EncodeOptions) (io.Reader, string, error) {
+	switch typedBody := body.(type) {
+	case []byte:
+		return bytes.NewReader(typedBody), ContentTypeOctetStream, nil
+	case string:
+		return strings.NewReader(typedBody), ContentTypeOctetStream, nil
+	case File:
+		return fileBody(typedBody)
+	case *File:
+		return fileBody(*typedBody)
+	case io.Reader:
+		return typedBody, ContentTypeOctetStream, nil
+	}
+
+	return nil, "", fmt.Errorf("%s bodies must be []byte, string, io.Reader or File, received %T", ContentTypeOctetStream, body)
+}
+
+// Sends the file as a body, keeping its content type when set. The file is opened on the
+// first Read, so it is not left open when the request fails before it is sent
+func fileBody(file File) (io.Reader, string, error) {
+	if file.IsEmpty() {
+		return nil, "", fs.ErrInvalid
+	}
+	body := newLazyBody(file.Open)
+	if file.ContentType != "" {
+		return body, file.ContentType, nil
+	}
+	return body, ContentTypeOctetStream, nil
+}
+
+func (OctetStreamCodec) Decode(data []byte, contentType string, v interface{}) error {
+	switch target := v.(type) {
+	case *[]byte:
+		*target = append((*target)[:0], data...)
+		return nil
+	case *string:
+		*target = string(data)
+		return nil
+	case io.Writer:
+		_, err := target.Write(data)
+		return err
+	}
+
+	return fmt.Errorf("%s bodies can only be decoded into *[]byte, *string or io.Writer, received %T", ContentTypeOctetStream, v)
+}
@@ -0,0 +1,233 @@
+package core
+
+import (
+	bytes "bytes"
+	xml "encoding/xml"
+	fmt "fmt"
+	io "io"
+	nullable "pets_go/nullable"
+	reflect "reflect"
+	sort "sort"
+	strings "strings"
+)
+
+// EnumPolicy controls how enum values unknown to the SDK are handled when decoding responses
+type EnumPolicy int
+
+const (
+	// Unknown enum values are kept as is and report false from their IsValid method,
+	// so responses from newer API versions still decode
+	EnumPolicyLenient EnumPolicy = iota
+	// Unknown enum values fail decoding with an UnknownEnumError
+	EnumPolicyStrict
+)
+
+// UnknownEnumError is returned when a decoded response holds an unknown enum value
+// while decoding with EnumPolicyStrict
+type UnknownEnumError struct {
+	// JSON path of the value, e.g. `[3].status`
+	Path  string
+	Type  string
+	Value string
+}
+
+func (e UnknownEnumError) Error() string {
+	return fmt.Sprintf("unknown %s value %q at %s", e.Type, e.Value, e.Path)
+}
+
+// UnknownFieldError is returned when a decoded response holds a property unknown
+// to the SDK while decoding with StrictDecoding
+type UnknownFieldError struct {
+	// JSON path of the object holding the property, e.g. `[3].category`
+	Path  string
+	Type  string
+	Field string
+}
+
+func (e UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q in %s at %s", e.Field, e.Type, e.Path)
+}
+
+// Decodes a JSON response body into v according to the client's decoding options
+func (c *CoreClient) DecodeJSON(data []byte, v interface{}) error {
+	return c.DecodeBody(ContentTypeJSON, data, v)
+}
+
+// Decodes a response body into v with the codec registered for its Content-Type header
+// value, bodies without a registered codec are decoded as JSON. Unknown XML elements are
+// ignored rather than captured in AdditionalProperties, so StrictDecoding only applies to JSON
+func (c *CoreClient) DecodeBody(contentType string, data []byte, v interface{}) error {
+	codec, ok := c.Codec(contentType)
+	if !ok {
+		if codec, ok = c.Codec(ContentTypeJSON); !ok {
+			codec = JSONCodec{}
+		}
+	}
+
+	if err := codec.Decode(data, contentType, v); err != nil {
+		return err
+	}
+	return c.checkDecoded(v)
+}
+
+// Applies the client's decoding policies to a decoded value
+func (c *CoreClient) checkDecoded(v interface{}) error {
+	if c.StrictDecoding {
+		if err := CheckUnknownFields(v); err != nil {
+			return err
+		}
+	}
+	if c.EnumPolicy == EnumPolicyStrict {
+		return CheckEnums(v)
+	}
+	return nil
+}
+
+// Decodes an XML document into v, a list is read from the children of the root element
+func decodeXML(data []byte, v interface{}) error {
+	list := reflect.ValueOf(v)
+	if list.Kind() != reflect.Ptr || list.Elem().Kind() != reflect.Slice || list.Elem().Type().Elem().Kind() == reflect.Uint8 {
+		return xml.Unmarshal(data, v)
+	}
+	list = list.Elem()
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				// root
+				depth++
+				list.Set(reflect.MakeSlice(list.Type(), 0, 0))
+				continue
+			}
+			item := reflect.New(list.Type().Elem())
+			if err := decoder.DecodeElement(item.Interface(), &token); err != nil {
+				return err
+			}
+			list.Set(reflect.Append(list, item.Elem()))
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+// enumLike is implemented by every enum in `types`
+type enumLike interface {
+	IsValid() bool
+}
+
+// CheckEnums walks the decoded value and returns an UnknownEnumError for the first
+// string enum whose IsValid method reports false
+func CheckEnums(v interface{}) error {
+	return walkDecoded(reflect.ValueOf(v), "", func(v reflect.Value, path string) (bool, error) {
+		if !v.CanInterface() || v.Kind() != reflect.String {
+			return true, nil
+		}
+		enum, ok := v.Interface().(enumLike)
+		if !ok {
+			return true, nil
+		}
+		if !enum.IsValid() {
+			return false, UnknownEnumError{Path: path, Type: v.Type().Name(), Value: v.String()}
+		}
+		return false, nil
+	})
+}
+
+// Name of the field the models in `types` capture unknown properties in
+const additionalPropertiesField = "AdditionalProperties"
+
+// CheckUnknownFields walks the decoded value and returns an UnknownFieldError for the
+// first model that captured properties unknown to the SDK in its AdditionalProperties
+func CheckUnknownFields(v interface{}) error {
+	return walkDecoded(reflect.ValueOf(v), "", func(v reflect.Value, path string) (bool, error) {
+		if v.Kind() != reflect.Struct {
+			return true, nil
+		}
+		additional := v.FieldByName(additionalPropertiesField)
+		if !additional.IsValid() || additional.Kind() != reflect.Map || additional.Len() == 0 {
+			return true, nil
+		}
+
+		// report the first name in sorted order so errors are stable
+		names := make([]string, 0, additional.Len())
+		for _, key := range additional.MapKeys() {
+			names = append(names, key.String())
+		}
+		sort.Strings(names)
+		return false, UnknownFieldError{Path: path, Type: v.Type().Name(), Field: names[0]}
+	})
+}
+
+// Calls visit on v and, as long as visit asks for it, on everything v holds, unwrapping
+// pointers, interfaces & nullables and following the JSON names of struct fields
+func walkDecoded(v reflect.Value, path string, visit func(v reflect.Value, path string) (bool, error)) error {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if descend, err := visit(v, path); err != nil || !descend {
+		return err
+	}
+
+	if v.CanInterface() {
+		if nullableLike, ok := v.Interface().(nullable.NullableLike); ok {
+			value, err := nullableLike.InterfaceValue()
+			if err != nil {
+				// null & undefined
+				return nil
+			}
+			return walkDecoded(reflect.ValueOf(value), path, visit)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return walkDecoded(v.Elem(), path, visit)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := walkDecoded(v.Index(i), IndexPath(path, i), visit); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := walkDecoded(iter.Value(), JoinPath(path, fmt.Sprint(iter.Key().Interface())), visit); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if err := walkDecoded(v.Field(i), JoinPath(path, name), visit); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+)
+
+// File is file content sent as a raw request body or as a multipart/form-data part.
+//
+// Content may be any io.Reader. Bodies built from a File can be replayed on retries
+// and redirects when the content can be re-read: files created from bytes, a path or
+// an fs.FS entry are re-opened for every attempt, and seekable readers are rewound to
+// the offset they started at
+type File struct {
+	// Filename reported to the server, if any
+	Filename string
+	// File content
+	Content io.Reader
+	// Optional size of the content in bytes, 0 if unknown
+	Size int64
+	// Optional Content-Type of the content, sniffed from the content when empty
+	ContentType string
+
+	// re-opens the content, set when the sdk owns the underlying handle
+	open func() (io.ReadCloser, error)
+}
+
+// Constructs a file from any reader
+func NewFile(filename string, content io.Reader) File {
+	file := File{Filename: filename, Content: content}
+	if buf, ok := content.(*bytes.Buffer); ok {
+		// snapshot buffered content so that it can be replayed
+		file = NewFileFromBytes(filename, buf.Bytes())
+	}
+
+	return file
+}
+
+// Constructs a file from in-memory content
+func NewFileFromBytes(filename string, content []byte) File {
+	return File{
+		Filename: filename,
+		Size:     int64(len(content)),
+		open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(content)), nil
+		},
+	}
+}
+
+// Constructs a file from a path on disk. The file is opened when the request
+// is sent and closed once it has been written
+func NewFileFromPath(filePath string) (File, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{
+		Filename: path.Base(info.Name()),
+		Size:     info.Size(),
+		open: func() (io.ReadCloser, error) {
+			return os.Open(filePath)
+		},
+	}, nil
+}
+
+// Constructs a file from an entry of a file system such as os.DirFS or embed.FS. The
+// entry is opened when the request is sent and closed once it has been written
+func NewFileFromFS(fsys fs.FS, name string) (File, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{
+		Filename: path.Base(name),
+		Size:     info.Size(),
+		open: func() (io.ReadCloser, error) {
+			return fsys.Open(name)
+		},
+	}, nil
+}
+
+// Reports whether the file has no content to send
+func (f File) IsEmpty() bool {
+	return f.open == nil && f.Content == nil
+}
+
+// Opens the file's content for reading. Content owned by the sdk is returned as a fresh
+// handle that the caller must close, caller-provided content is never closed
+func (f File) Open() (io.ReadCloser, error) {
+	if f.open != nil {
+		return f.open()
+	}
+	if f.Content == nil {
+		return nil, fs.ErrInvalid
+	}
+
+	return io.NopCloser(f.Content), nil
+}
+
+// Attaches the file as the raw body of the request, setting its Content-Type and, when
+// known, its Content-Length. GetBody is set when the content can be replayed
+func SetFileBody(req *http.Request, file File) error {
+	fileContent, err := file.Open()
+	if err != nil {
+		return err
+	}
+	var body io.ReadCloser = fileContent
+
+	size := file.Size
+	var getBody func() (io.ReadCloser, error)
+	if file.open != nil {
+		getBody = file.open
+	} else if seeker, ok := file.Content.(io.Seeker); ok {
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		if size == 0 {
+			end, err := seeker.Seek(0, io.SeekEnd)
+			if err != nil {
+				return err
+			}
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return err
+			}
+			size = end - start
+		}
+		getBody = func() (io.ReadCloser, error) {
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+			return io.NopCloser(file.Content), nil
+		}
+	}
+
+	contentType := file.ContentType
+	if contentType == "" {
+		buffered := bufio.NewReaderSize(fileContent, 512)
+		contentType = sniffContentType(buffered, file.Filename)
+		body = struct {
+			io.Reader
+			io.Closer
+		}{buffered, fileContent}
+	}
+
+	req.Body = body
+	req.GetBody = getBody
+	req.ContentLength = size
+	if size == 0 {
+		// unknown length, sent chunked
+		req.ContentLength = -1
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	return nil
+}
+
+// lazyBody opens its content on the first Read, so a body that is never sent holds no
+// resources. Closing it before the first Read skips opening the content altogether
+type lazyBody struct {
+	open func() (io.ReadCloser, error)
+
+	mu     sync.Mutex
+	body   io.ReadCloser
+	err    error
+	closed bool
+}
+
+func newLazyBody(open func() (io.ReadCloser, error)) *lazyBody {
+	return &lazyBody{open: open}
+}
+
+func (b *lazyBody) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return 0, os.ErrClosed
+	}
+	if b.body == nil && b.err == nil {
+		b.body, b.err = b.open()
+	}
+	body, err := b.body, b.err
+	b.mu.Unlock()
+
+	if err != nil {
+		return 0, err
+	}
+	// not read under the lock, Close must be able to interrupt a blocked Read
+	return body.Read(p)
+}
+
+func (b *lazyBody) Close() error {
+	b.mu.Lock()
+	body := b.body
+	b.closed = true
+	b.mu.Unlock()
+
+	if body == nil {
+		return nil
+	}
+	return body.Close()
+}
@@ -1,16 +1,25 @@
 package core
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"io"
+	"io/fs"
+	"mime"
 	"mime/multipart"
+	"net/http"
+	"net/textproto"
 	"os"
 	"path"
 	"pets_go/nullable"
 	"reflect"
+	"strings"
 )
 
 // Utility to open file that you are certain exists
+//
+// Deprecated: use NewFileFromPath, which opens the file only while it is being sent
 func MustOpenFile(path string) os.File {
 	file, err := os.Open(path)
 	if err != nil {
@@ -21,6 +30,8 @@ func MustOpenFile(path string) os.File {
 }
 
 // Creates an in-memory file with the given name and content
+//
+// Deprecated: backed by a temp file that is never removed, use NewFileFromBytes
 func NewInMemoryFile(name string, content string) os.File {
 	tmpFile, err := os.CreateTemp("", "memory-file-*")
 	if err != nil {
@@ -36,28 +47,106 @@ func NewInMemoryFile(name string, content string) os.File {
 	return *tmpFile
 }
 
+// FormDataField is a single named value of a multipart/form-data body,
+// see AddToFormDataWriter for the supported values
+type FormDataField struct {
+	Name  string
+	Value interface{}
+}
+
+// Streams the fields as a multipart/form-data body through an io.Pipe. The returned
+// content type carries the body's boundary. Any error encountered while writing a part
+// is returned from the body's Read, failing the request it is attached to.
+//
+// Parts are written from the first Read on, so a body that is closed or dropped unread,
+// e.g. when a request fails before it is sent, starts no goroutine and opens no file
+func MultipartBody(fields ...FormDataField) (io.ReadCloser, string) {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	body := newLazyBody(func() (io.ReadCloser, error) {
+		pipeReader, pipeWriter := io.Pipe()
+		writer := multipart.NewWriter(pipeWriter)
+		if err := writer.SetBoundary(boundary); err != nil {
+			return nil, err
+		}
+
+		go func() {
+			for _, field := range fields {
+				if err := AddToFormDataWriter(writer, field.Name, field.Value); err != nil {
+					pipeWriter.CloseWithError(err)
+					return
+				}
+			}
+			pipeWriter.CloseWithError(writer.Close())
+		}()
+
+		return pipeReader, nil
+	})
+
+	return body, "multipart/form-data; boundary=" + boundary
+}
+
+// Attaches a streamed multipart/form-data body to the request and sets its Content-Type
+func SetMultipartBody(req *http.Request, fields ...FormDataField) {
+	body, contentType := MultipartBody(fields...)
+	req.Body = body
+	req.ContentLength = -1
+	req.GetBody = nil
+	req.Header.Set("Content-Type", contentType)
+}
+
 // Handles adding files, fields, or arrays of each to a form data writer
 func AddToFormDataWriter(writer *multipart.Writer, field string, value interface{}) error {
+	if value == nil {
+		return addFieldToFormDataWriter(writer, field, value)
+	}
+
 	reflectVal := reflect.ValueOf(value)
 	kind := reflectVal.Kind()
 	if kind == reflect.Array || kind == reflect.Slice {
+		if byteVal, ok := value.([]byte); ok {
+			// raw bytes are file content named after their field
+			return addFileToFormDataWriter(writer, field, File{Filename: field, Content: bytes.NewReader(byteVal)})
+		}
 		for i := 0; i < reflectVal.Len(); i++ {
 			item := reflectVal.Index(i).Interface()
 			if err := AddToFormDataWriter(writer, field, item); err != nil {
 				return err
 			}
 		}
-	} else if file, ok := value.(os.File); ok {
-		addFileToFormDataWriter(writer, field, file)
-	} else if nullableLike, ok := nullable.IsNullableInterface(value); ok {
-		if nullableVal, err := nullableLike.InterfaceValue(); err == nil {
-			AddToFormDataWriter(writer, field, nullableVal)
+		return nil
+	}
+
+	switch typedVal := value.(type) {
+	case File:
+		return addFileToFormDataWriter(writer, field, typedVal)
+	case *File:
+		return addFileToFormDataWriter(writer, field, *typedVal)
+	case os.File:
+		return addFileToFormDataWriter(writer, field, File{Filename: path.Base(typedVal.Name()), Content: &typedVal})
+	case fs.File:
+		info, err := typedVal.Stat()
+		if err != nil {
+			return err
 		}
-	} else {
-		addFieldToFormDataWriter(writer, field, value)
+		return addFileToFormDataWriter(writer, field, File{Filename: info.Name(), Content: typedVal})
 	}
 
-	return nil
+	if nullableLike, ok := nullable.IsNullableInterface(value); ok {
+		// undefined & null nullables are omitted from the form
+		nullableVal, err := nullableLike.InterfaceValue()
+		if err != nil {
+			return nil
+		}
+		return AddToFormDataWriter(writer, field, nullableVal)
+	}
+
+	if reader, ok := value.(io.Reader); ok {
+		// readers without a filename are named after their field
+		return addFileToFormDataWriter(writer, field, File{Filename: field, Content: reader})
+	}
+
+	return addFieldToFormDataWriter(writer, field, value)
 }
 
 // Adds non-file to form data writer
@@ -66,21 +155,56 @@ func addFieldToFormDataWriter(writer *multipart.Writer, field string, value inte
 	if err != nil {
 		return err
 	}
-	label.Write([]byte(FmtStringParam(value)))
-	return nil
+	_, err = label.Write([]byte(FmtStringParam(value)))
+	return err
 }
 
-// Adds file to form data writer
-func addFileToFormDataWriter(writer *multipart.Writer, field string, file os.File) error {
-	part, err := writer.CreateFormFile(field, path.Base(file.Name()))
+// Adds file to form data writer, the part's Content-Type is taken from the file
+// if set, otherwise it is sniffed from the first 512 bytes of content
+func addFileToFormDataWriter(writer *multipart.Writer, field string, file File) error {
+	fileContent, err := file.Open()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed opening form file for field '%s': %w", field, err)
 	}
+	defer fileContent.Close()
 
-	_, err = io.Copy(part, &file)
+	content := bufio.NewReaderSize(fileContent, 512)
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = sniffContentType(content, file.Filename)
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		escapeQuotes(field), escapeQuotes(file.Filename)))
+	header.Set("Content-Type", contentType)
+
+	part, err := writer.CreatePart(header)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = io.Copy(part, content)
+	return err
+}
+
+// Detects the content type of buffered content, falling back on the filename's
+// extension when the content itself is not recognized
+func sniffContentType(content *bufio.Reader, filename string) string {
+	head, _ := content.Peek(512)
+	contentType := http.DetectContentType(head)
+
+	if strings.HasPrefix(contentType, "application/octet-stream") || strings.HasPrefix(contentType, "text/plain") {
+		if extType := mime.TypeByExtension(path.Ext(filename)); extType != "" {
+			return extType
+		}
+	}
+
+	return contentType
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
 }
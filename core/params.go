@@ -1,14 +1,32 @@
 package core
 
 import (
+	bytes "bytes"
 	json "encoding/json"
 	fmt "fmt"
 	url "net/url"
+	nullable "pets_go/nullable"
 	reflect "reflect"
+	sort "sort"
 	strconv "strconv"
 	strings "strings"
 )
 
+// ArrayEncoding selects how a list is written to a form style query parameter
+type ArrayEncoding int
+
+const (
+	// One parameter per item, e.g. `status=available&status=sold`
+	ArrayEncodingExplode ArrayEncoding = iota
+	// A single comma separated parameter, e.g. `status=available,sold`
+	ArrayEncodingComma
+)
+
+// Reports whether the encoding explodes lists, as passed to AddQueryParam
+func (e ArrayEncoding) Explode() bool {
+	return e != ArrayEncodingComma
+}
+
 func FmtStringParam(value interface{}) string {
 	if value == nil {
 		return "null"
@@ -45,6 +63,19 @@ func FmtStringParam(value interface{}) string {
 }
 
 func AddQueryParam(queryParams url.Values, paramName string, value interface{}, style string, explode bool) {
+	// undefined nullables are omitted, null & set nullables are encoded by their value
+	if value != nil {
+		if nullableLike, ok := nullable.IsNullableInterface(value); ok {
+			if nullableLike.IsUndefined() {
+				return
+			}
+			value, _ = nullableLike.InterfaceValue()
+			if nullableLike.IsNull() {
+				value = nil
+			}
+		}
+	}
+
 	if style == "form" {
 		addFormQueryParam(queryParams, paramName, value, explode)
 	} else if style == "spaceDelimited" {
@@ -60,6 +91,11 @@ func AddQueryParam(queryParams url.Values, paramName string, value interface{},
 }
 
 func addFormQueryParam(queryParams url.Values, paramName string, value interface{}, explode bool) {
+	if obj, ok := value.(orderedObject); ok {
+		addFormObjectQueryParam(queryParams, paramName, obj, explode)
+		return
+	}
+
 	v := reflect.ValueOf(value)
 	// handle pointers
 	if v.Kind() == reflect.Ptr {
@@ -68,36 +104,15 @@ func addFormQueryParam(queryParams url.Values, paramName string, value interface
 
 	switch v.Kind() {
 	case reflect.Map:
-		// explode form maps should be encoded like /users?key0=val0&key1=val1
-		// the input param name will be omitted
-		// non-explode form maps should be encoded like /users?id=key0,val0,key1,val1
-		var chunks []string
-		for _, mapKey := range v.MapKeys() {
-			mapVal := FmtStringParam(v.MapIndex(mapKey).Interface())
-
-			chunks = append(chunks, mapKey.String(), mapVal)
-
-			if explode {
-				queryParams.Add(mapKey.String(), mapVal)
-			}
-		}
-
-		if !explode && len(chunks) > 0 {
-			queryParams.Add(paramName, strings.Join(chunks, ","))
-		}
+		addFormObjectQueryParam(queryParams, paramName, mapToOrderedObject(v), explode)
 
 	case reflect.Struct:
 		// structs that are part of a query param must implement json marshaling
-		// marshal then unmarshal back to an interface to process.
-		jsonData, err := json.Marshal(value)
+		// marshal then decode back to an ordered object to process.
+		jsonInterface, err := toOrderedJSON(value)
 		if err == nil {
-			var jsonInterface interface{}
-			err = json.Unmarshal(jsonData, &jsonInterface)
-
-			if err == nil {
-				addFormQueryParam(queryParams, paramName, jsonInterface, explode)
-				return
-			}
+			addFormQueryParam(queryParams, paramName, jsonInterface, explode)
+			return
 		}
 
 		fmt.Printf("Failed converting complex struct into native map/primitive: %v", err)
@@ -124,6 +139,26 @@ func addFormQueryParam(queryParams url.Values, paramName string, value interface
 	}
 }
 
+func addFormObjectQueryParam(queryParams url.Values, paramName string, obj orderedObject, explode bool) {
+	// explode form maps should be encoded like /users?key0=val0&key1=val1
+	// the input param name will be omitted
+	// non-explode form maps should be encoded like /users?id=key0,val0,key1,val1
+	var chunks []string
+	for _, field := range obj {
+		fieldVal := FmtStringParam(field.Value)
+
+		chunks = append(chunks, field.Key, fieldVal)
+
+		if explode {
+			queryParams.Add(field.Key, fieldVal)
+		}
+	}
+
+	if !explode && len(chunks) > 0 {
+		queryParams.Add(paramName, strings.Join(chunks, ","))
+	}
+}
+
 func addSpaceDelimitedQueryParam(queryParams url.Values, paramName string, value interface{}, explode bool) {
 	v := reflect.ValueOf(value)
 	// handle pointers
@@ -181,6 +216,11 @@ func addDeepObjQueryParam(queryParams url.Values, paramName string, value interf
 		v = v.Elem()
 	}
 
+	if _, ok := value.(orderedObject); ok {
+		encodeDeepObjectKey(queryParams, paramName, value)
+		return
+	}
+
 	switch v.Kind() {
 	case reflect.Struct, reflect.Map, reflect.Array, reflect.Slice:
 		encodeDeepObjectKey(queryParams, paramName, value)
@@ -194,6 +234,13 @@ func addDeepObjQueryParam(queryParams url.Values, paramName string, value interf
 }
 
 func encodeDeepObjectKey(queryParams url.Values, key string, value interface{}) {
+	if obj, ok := value.(orderedObject); ok {
+		for _, field := range obj {
+			encodeDeepObjectKey(queryParams, fmt.Sprintf("%s[%s]", key, field.Key), field.Value)
+		}
+		return
+	}
+
 	v := reflect.ValueOf(value)
 	// handle pointers
 	if v.Kind() == reflect.Ptr {
@@ -202,26 +249,15 @@ func encodeDeepObjectKey(queryParams url.Values, key string, value interface{})
 
 	switch v.Kind() {
 	case reflect.Map:
-		for _, mapKey := range v.MapKeys() {
-			encodeDeepObjectKey(
-				queryParams,
-				fmt.Sprintf("%s[%s]", key, mapKey.String()),
-				v.MapIndex(mapKey).Interface(),
-			)
-		}
+		encodeDeepObjectKey(queryParams, key, mapToOrderedObject(v))
 
 	case reflect.Struct:
 		// structs that are part of a query param must implement json marshaling
-		// marshal then unmarshal back to an interface to process.
-		jsonData, err := json.Marshal(value)
+		// marshal then decode back to an ordered object to process.
+		jsonInterface, err := toOrderedJSON(value)
 		if err == nil {
-			var jsonInterface interface{}
-			err = json.Unmarshal(jsonData, &jsonInterface)
-
-			if err == nil {
-				encodeDeepObjectKey(queryParams, key, jsonInterface)
-				return
-			}
+			encodeDeepObjectKey(queryParams, key, jsonInterface)
+			return
 		}
 
 		fmt.Printf("Failed converting complex struct into native map/primitive: %v", err)
@@ -237,44 +273,51 @@ func encodeDeepObjectKey(queryParams url.Values, key string, value interface{})
 
 }
 
-// Encodes any struct that supports json encodeing to url values
+// Encodes any struct that supports json encodeing to url values.
+// Struct fields are written in their declared order, map keys in sorted order
 func FormUrlEncodedBody(value interface{}, styleMap map[string]string, explodeMap map[string]bool) (*strings.Reader, error) {
-	v := reflect.ValueOf(value)
-	// handle pointers
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-	}
-	switch v.Kind() {
-	case reflect.Map:
+	if obj, ok := value.(orderedObject); ok {
 		formValues := url.Values{}
-		for _, mapKey := range v.MapKeys() {
-			key := mapKey.String()
-			style, styleOk := styleMap[key]
+		keyOrder := []string{}
+		for _, field := range obj {
+			style, styleOk := styleMap[field.Key]
 			if !styleOk {
 				style = "form"
 			}
-			explode, explodeOk := explodeMap[key]
+			explode, explodeOk := explodeMap[field.Key]
 			if !explodeOk {
 				explode = style == "form"
 			}
 
-			AddQueryParam(formValues, key, v.MapIndex(mapKey).Interface(), style, explode)
+			fieldValues := url.Values{}
+			AddQueryParam(fieldValues, field.Key, field.Value, style, explode)
+			for _, fieldKey := range sortedValueKeys(fieldValues) {
+				if _, seen := formValues[fieldKey]; !seen {
+					keyOrder = append(keyOrder, fieldKey)
+				}
+				formValues[fieldKey] = append(formValues[fieldKey], fieldValues[fieldKey]...)
+			}
 		}
 
-		bodyBuf := strings.NewReader(formValues.Encode())
+		bodyBuf := strings.NewReader(encodeValuesInOrder(formValues, keyOrder))
 		return bodyBuf, nil
+	}
+
+	v := reflect.ValueOf(value)
+	// handle pointers
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		return FormUrlEncodedBody(mapToOrderedObject(v), styleMap, explodeMap)
 
 	case reflect.Struct:
 		// structs that are part of a form url encoded body must implement json marshaling
-		// marshal then unmarshal back to an interface to process.
-		jsonData, err := json.Marshal(value)
+		// marshal then decode back to an ordered object to process.
+		jsonInterface, err := toOrderedJSON(value)
 		if err == nil {
-			var jsonInterface interface{}
-			err = json.Unmarshal(jsonData, &jsonInterface)
-
-			if err == nil {
-				return FormUrlEncodedBody(jsonInterface, styleMap, explodeMap)
-			}
+			return FormUrlEncodedBody(jsonInterface, styleMap, explodeMap)
 		}
 		return &strings.Reader{}, err
 
@@ -282,3 +325,163 @@ func FormUrlEncodedBody(value interface{}, styleMap map[string]string, explodeMa
 		return &strings.Reader{}, fmt.Errorf("x-www-form-urlencoded data must be a map or a struct at the top level")
 	}
 }
+
+// CanonicalQueryString encodes query values in a stable, canonical form suitable for
+// request signing, cache keys and golden-file comparisons. Keys are sorted, the values
+// of a repeated key keep their order, and both are percent-encoded per RFC 3986
+// (spaces become %20 rather than +)
+func CanonicalQueryString(values url.Values) string {
+	return encodeValuesInOrder(values, sortedValueKeys(values))
+}
+
+// CanonicalURL returns the URL string with its query rewritten by CanonicalQueryString
+func CanonicalURL(u *url.URL) (string, error) {
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return "", err
+	}
+
+	canonical := *u
+	canonical.RawQuery = CanonicalQueryString(values)
+	canonical.ForceQuery = false
+
+	return canonical.String(), nil
+}
+
+func encodeValuesInOrder(values url.Values, keys []string) string {
+	var buf strings.Builder
+	for _, key := range keys {
+		keyEscaped := escapeQueryComponent(key)
+		for _, val := range values[key] {
+			if buf.Len() > 0 {
+				buf.WriteByte('&')
+			}
+			buf.WriteString(keyEscaped)
+			buf.WriteByte('=')
+			buf.WriteString(escapeQueryComponent(val))
+		}
+	}
+
+	return buf.String()
+}
+
+func escapeQueryComponent(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func sortedValueKeys(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// orderedObject is a JSON object that keeps the order its keys were decoded in.
+// encoding/json writes struct fields in declaration order and map keys sorted,
+// so an orderedObject decoded from marshaled JSON has a canonical key order
+type orderedObject []orderedField
+type orderedField struct {
+	Key   string
+	Value interface{}
+}
+
+// Marshals the object back to JSON without losing its key order
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString("{")
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyData, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		valData, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyData)
+		buf.WriteByte(':')
+		buf.Write(valData)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// Converts a reflected map into an orderedObject sorted by its formatted keys
+func mapToOrderedObject(v reflect.Value) orderedObject {
+	obj := make(orderedObject, 0, v.Len())
+	for _, mapKey := range v.MapKeys() {
+		obj = append(obj, orderedField{
+			Key:   FmtStringParam(mapKey.Interface()),
+			Value: v.MapIndex(mapKey).Interface(),
+		})
+	}
+	sort.SliceStable(obj, func(i, j int) bool { return obj[i].Key < obj[j].Key })
+
+	return obj
+}
+
+// Marshals the value to JSON and decodes it back into primitives, slices and orderedObjects
+func toOrderedJSON(value interface{}) (interface{}, error) {
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+
+	return decodeOrderedJSON(decoder)
+}
+
+func decodeOrderedJSON(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch delim := token.(type) {
+	case json.Delim:
+		if delim == '{' {
+			obj := orderedObject{}
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				fieldVal, err := decodeOrderedJSON(decoder)
+				if err != nil {
+					return nil, err
+				}
+				obj = append(obj, orderedField{Key: keyToken.(string), Value: fieldVal})
+			}
+			// consume closing delimiter
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+			return obj, nil
+		}
+
+		list := []interface{}{}
+		for decoder.More() {
+			item, err := decodeOrderedJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		// consume closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return list, nil
+
+	default:
+		return token, nil
+	}
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// UploadProgress is a snapshot of a request body being sent
+type UploadProgress struct {
+	// Bytes of the body sent so far
+	BytesSent int64
+	// Total size of the body in bytes, -1 if unknown
+	Total int64
+	// Average send rate in bytes per second since the upload started
+	Rate float64
+}
+
+// Reports the progress of the request body as it is sent. The callback is invoked
+// after every chunk read by the transport, from the goroutine sending the request
+func WithUploadProgress(onProgress func(UploadProgress)) RequestModifier {
+	return func(req *http.Request) error {
+		return wrapRequestBody(req, func(body io.ReadCloser) io.ReadCloser {
+			return &transferReader{req: req, body: body, total: req.ContentLength, onProgress: onProgress}
+		})
+	}
+}
+
+// Caps the rate the request body is sent at. Waiting on the limit is aborted when the
+// request's context is cancelled
+func WithUploadRateLimit(bytesPerSecond int64) RequestModifier {
+	return func(req *http.Request) error {
+		if bytesPerSecond <= 0 {
+			return nil
+		}
+		return wrapRequestBody(req, func(body io.ReadCloser) io.ReadCloser {
+			return &transferReader{req: req, body: body, total: req.ContentLength, bytesPerSecond: bytesPerSecond}
+		})
+	}
+}
+
+// Wraps the request body, and any replay of it, with the given wrapper
+func wrapRequestBody(req *http.Request, wrap func(io.ReadCloser) io.ReadCloser) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	req.Body = wrap(req.Body)
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return wrap(body), nil
+		}
+	}
+
+	return nil
+}
+
+// transferReader reports progress of and throttles reads from a request body. The
+// request's context is read on every call so that WithContext may be applied after
+type transferReader struct {
+	req            *http.Request
+	body           io.ReadCloser
+	total          int64
+	sent           int64
+	start          time.Time
+	onProgress     func(UploadProgress)
+	bytesPerSecond int64
+}
+
+func (r *transferReader) Read(p []byte) (int, error) {
+	ctx := r.req.Context()
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if r.start.IsZero() {
+		r.start = time.Now()
+	}
+
+	if r.bytesPerSecond > 0 {
+		// read in chunks of at most a tenth of a second's worth of bytes
+		chunk := r.bytesPerSecond / 10
+		if chunk < 1 {
+			chunk = 1
+		}
+		if int64(len(p)) > chunk {
+			p = p[:chunk]
+		}
+	}
+
+	n, err := r.body.Read(p)
+	r.sent += int64(n)
+
+	if r.bytesPerSecond > 0 && n > 0 {
+		expected := time.Duration(float64(r.sent) / float64(r.bytesPerSecond) * float64(time.Second))
+		if wait := expected - time.Since(r.start); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return n, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	if r.onProgress != nil && (n > 0 || err == io.EOF) {
+		total := r.total
+		if total <= 0 {
+			total = -1
+		}
+		rate := 0.0
+		if elapsed := time.Since(r.start).Seconds(); elapsed > 0 {
+			rate = float64(r.sent) / elapsed
+		}
+		r.onProgress(UploadProgress{BytesSent: r.sent, Total: total, Rate: rate})
+	}
+
+	return n, err
+}
+
+func (r *transferReader) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	fmt "fmt"
+	url "net/url"
+	strings "strings"
+)
+
+// FieldError describes a single field of a request failing validation
+type FieldError struct {
+	// JSON path of the field, e.g. `tags[0].id`
+	Path    string
+	Message string
+}
+
+// ValidationError is returned when a request fails client-side validation, before it is sent
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fmt.Sprintf("%s: %s", fieldErr.Path, fieldErr.Message)
+	}
+	return "request validation failed: " + strings.Join(messages, "; ")
+}
+
+// Validator collects field errors while a request is validated
+type Validator struct {
+	errors []FieldError
+}
+
+// Records a field error
+func (v *Validator) Add(path string, message string) {
+	v.errors = append(v.errors, FieldError{Path: path, Message: message})
+}
+
+// Records a field error unless ok
+func (v *Validator) Check(ok bool, path string, message string) {
+	if !ok {
+		v.Add(path, message)
+	}
+}
+
+// Records an error if the value is negative
+func (v *Validator) NonNegative(value int, path string) {
+	v.Check(value >= 0, path, "must not be negative")
+}
+
+// Records an error if the value is not an absolute URL
+func (v *Validator) AbsoluteURL(value string, path string) {
+	parsed, err := url.ParseRequestURI(value)
+	v.Check(err == nil && parsed.Scheme != "" && parsed.Host != "", path, "must be an absolute URL")
+}
+
+// Returns a ValidationError holding every recorded field error, nil if there were none
+func (v *Validator) Err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return ValidationError{Errors: v.errors}
+}
+
+// Joins a field name onto a JSON path
+func JoinPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// Appends an array index to a JSON path
+func IndexPath(path string, index int) string {
+	return fmt.Sprintf("%s[%d]", path, index)
+}
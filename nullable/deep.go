@@ -0,0 +1,185 @@
+package nullable
+
+import (
+	"reflect"
+)
+
+// DeepCopy returns a copy of v that shares no slices, maps or pointers with it. Values
+// with a `Clone() T` method, including every Nullable, are copied with that method.
+// Channels and funcs are copied as is. A type's own Clone method must call DeepCopy
+// on a method-less alias of the type to avoid recursing into itself
+func DeepCopy[T any](v T) T {
+	copied := deepCopyValue(reflect.ValueOf(&v).Elem())
+	return copied.Interface().(T)
+}
+
+// DeepEqual reports whether a and b are deeply equal. Unlike reflect.DeepEqual, values
+// with an `Equal(T) bool` method, such as time.Time or Nullable, are compared with it
+func DeepEqual[T any](a T, b T) bool {
+	return deepEqualValues(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+}
+
+// Calls the value's `Clone() T` method if it has one
+func callClone(v reflect.Value) (reflect.Value, bool) {
+	if !v.CanInterface() {
+		return reflect.Value{}, false
+	}
+	method := v.MethodByName("Clone")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 || method.Type().Out(0) != v.Type() {
+		return reflect.Value{}, false
+	}
+	return method.Call(nil)[0], true
+}
+
+// Calls a's `Equal(T) bool` method if it has one
+func callEqual(a reflect.Value, b reflect.Value) (bool, bool) {
+	if !a.CanInterface() || !b.CanInterface() {
+		return false, false
+	}
+	method := a.MethodByName("Equal")
+	if !method.IsValid() {
+		return false, false
+	}
+	if t := method.Type(); t.NumIn() != 1 || t.In(0) != a.Type() || t.NumOut() != 1 || t.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	return method.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+func deepCopyValue(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface {
+		if cloned, ok := callClone(v); ok {
+			return cloned
+		}
+	}
+
+	copied := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return copied
+		}
+		elem := reflect.New(v.Type().Elem())
+		elem.Elem().Set(deepCopyValue(v.Elem()))
+		copied.Set(elem)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return copied
+		}
+		copied.Set(deepCopyValue(v.Elem()))
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return copied
+		}
+		copied.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+
+	case reflect.Map:
+		if v.IsNil() {
+			return copied
+		}
+		copied.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+
+	case reflect.Struct:
+		// copy the struct wholesale, including unexported fields, then
+		// replace the exported fields with deep copies
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(deepCopyValue(v.Field(i)))
+			}
+		}
+
+	default:
+		copied.Set(v)
+	}
+
+	return copied
+}
+
+func deepEqualValues(a reflect.Value, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	if equal, ok := callEqual(a, b); ok {
+		return equal
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return deepEqualValues(a.Elem(), b.Elem())
+
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() {
+			return false
+		}
+		fallthrough
+	case reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !deepEqualValues(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() || a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			bVal := b.MapIndex(iter.Key())
+			if !bVal.IsValid() || !deepEqualValues(iter.Value(), bVal) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !deepEqualValues(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	}
+
+	return false
+}
@@ -0,0 +1,56 @@
+package nullable
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Formats the Nullable as `undefined`, `null` or its value formatted with the same verb
+// and flags. %#v is formatted with GoString
+func (n Nullable[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, n.GoString())
+		return
+	}
+
+	switch n.state {
+	case stateUndefined:
+		fmt.Fprint(f, "undefined")
+	case stateNull:
+		fmt.Fprint(f, "null")
+	default:
+		fmt.Fprintf(f, formatDirective(f, verb), n.value)
+	}
+}
+
+// Returns the Go syntax constructing the Nullable, used by %#v
+func (n Nullable[T]) GoString() string {
+	typeName := reflect.TypeOf((*T)(nil)).Elem().String()
+	switch n.state {
+	case stateUndefined:
+		return "nullable.Nullable[" + typeName + "]{}"
+	case stateNull:
+		return "nullable.NewNull[" + typeName + "]()"
+	default:
+		return fmt.Sprintf("nullable.NewValue[%s](%#v)", typeName, n.value)
+	}
+}
+
+// Rebuilds the formatting directive described by the state & verb
+func formatDirective(f fmt.State, verb rune) string {
+	directive := "%"
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			directive += string(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		directive += strconv.Itoa(width)
+	}
+	if precision, ok := f.Precision(); ok {
+		directive += "." + strconv.Itoa(precision)
+	}
+
+	return directive + string(verb)
+}
@@ -0,0 +1,450 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MarshalStruct marshals a struct to a JSON object the way encoding/json does, while also
+// omitting Nullable fields that are undefined and tagged with omitempty. Fields of embedded
+// structs are promoted and `,string` options honoured following encoding/json's rules. It
+// is meant to be called from a struct's MarshalJSON on a method-less alias of the struct:
+//
+//	func (p Pet) MarshalJSON() ([]byte, error) {
+//		type alias Pet
+//		return nullable.MarshalStruct(alias(p))
+//	}
+func MarshalStruct(v interface{}) ([]byte, error) {
+	return MarshalStructWithAdditional(v, nil)
+}
+
+// MarshalStructWithAdditional works like MarshalStruct and appends the additional properties
+// after the struct's own fields, sorted by name. Properties named like one of the struct's
+// fields are skipped, the field always wins
+func MarshalStructWithAdditional(v interface{}, additional map[string]json.RawMessage) ([]byte, error) {
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return []byte("null"), nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nullable.MarshalStruct expects a struct, received %s", val.Kind())
+	}
+
+	buf := bytes.NewBufferString("{")
+	written := 0
+	for _, field := range cachedStructFields(val.Type()) {
+		fieldVal, ok := fieldByIndex(val, field.index)
+		if !ok {
+			// promoted through a nil embedded pointer
+			continue
+		}
+		if field.omitEmpty {
+			if field.nullable {
+				// read the state directly, boxing the Nullable to call IsUndefined allocates
+				if state(fieldVal.Field(nullableStateIndex).Uint()) == stateUndefined {
+					continue
+				}
+			} else if isEmptyJSONValue(fieldVal) {
+				continue
+			}
+		}
+
+		fieldData, err := json.Marshal(fieldVal.Interface())
+		if err != nil {
+			return nil, err
+		}
+		if field.quoted && string(fieldData) != "null" {
+			if fieldData, err = json.Marshal(string(fieldData)); err != nil {
+				return nil, err
+			}
+		}
+
+		if written > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(field.encodedName)
+		buf.WriteByte(':')
+		buf.Write(fieldData)
+		written++
+	}
+
+	if len(additional) > 0 {
+		fields := cachedStructFields(val.Type())
+		names := make([]string, 0, len(additional))
+		for name := range additional {
+			if !isKnownField(fields, name) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			encodedName, _ := json.Marshal(name)
+			// compact to validate the raw value and keep the output on a single line
+			fieldData := bytes.Buffer{}
+			if err := json.Compact(&fieldData, additional[name]); err != nil {
+				return nil, fmt.Errorf("invalid additional property %q: %w", name, err)
+			}
+
+			if written > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(encodedName)
+			buf.WriteByte(':')
+			buf.Write(fieldData.Bytes())
+			written++
+		}
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalStruct decodes a JSON object into the struct pointed to by v the way encoding/json
+// does and returns the object's properties that match none of the struct's fields, or nil if
+// there are none. Like MarshalStruct it is meant to be called on a method-less alias:
+//
+//	func (p *Pet) UnmarshalJSON(data []byte) error {
+//		type alias Pet
+//		additional, err := nullable.UnmarshalStruct(data, (*alias)(p))
+//		...
+//	}
+func UnmarshalStruct(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("nullable.UnmarshalStruct expects a struct pointer, received %s", val.Type())
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	// the data was validated by decoding it, the unknown properties are collected by
+	// scanning its keys rather than parsing the object a second time
+	fields := cachedStructFields(val.Elem().Type())
+	var additional map[string]json.RawMessage
+	i := skipJSONSpace(data, 0)
+	if data[i] != '{' {
+		// null
+		return nil, nil
+	}
+	for i = skipJSONSpace(data, i+1); data[i] != '}'; i = skipJSONSpace(data, i) {
+		if data[i] == ',' {
+			i = skipJSONSpace(data, i+1)
+		}
+		keyEnd := skipJSONString(data, i)
+		key := data[i:keyEnd]
+		valueStart := skipJSONSpace(data, skipJSONSpace(data, keyEnd)+1)
+		i = skipJSONValue(data, valueStart)
+
+		if isKnownEncodedField(fields, key) {
+			continue
+		}
+		var name string
+		if err := json.Unmarshal(key, &name); err != nil {
+			return nil, err
+		}
+		if additional == nil {
+			additional = map[string]json.RawMessage{}
+		}
+		additional[name] = append(json.RawMessage(nil), data[valueStart:i]...)
+	}
+
+	return additional, nil
+}
+
+// Reports whether the quoted property name matches one of the fields, comparing it as is
+// unless either holds escapes
+func isKnownEncodedField(fields []structField, key []byte) bool {
+	if bytes.IndexByte(key, '\\') >= 0 {
+		var name string
+		return json.Unmarshal(key, &name) == nil && isKnownField(fields, name)
+	}
+	for _, field := range fields {
+		if bytes.IndexByte(field.encodedName, '\\') >= 0 {
+			if strings.EqualFold(field.name, string(key[1:len(key)-1])) {
+				return true
+			}
+		} else if bytes.EqualFold(field.encodedName, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The following scan JSON known to be valid, returning the index following what they skip
+
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+func skipJSONString(data []byte, i int) int {
+	for i++; data[i] != '"'; i++ {
+		if data[i] == '\\' {
+			i++
+		}
+	}
+	return i + 1
+}
+
+func skipJSONValue(data []byte, i int) int {
+	switch data[i] {
+	case '"':
+		return skipJSONString(data, i)
+	case '{', '[':
+		depth := 0
+		for ; ; i++ {
+			switch data[i] {
+			case '"':
+				i = skipJSONString(data, i) - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				if depth--; depth == 0 {
+					return i + 1
+				}
+			}
+		}
+	default:
+		// numbers, true, false & null
+		for i < len(data) && data[i] != ',' && data[i] != '}' && data[i] != ']' && data[i] != ' ' &&
+			data[i] != '\t' && data[i] != '\n' && data[i] != '\r' {
+			i++
+		}
+		return i
+	}
+}
+
+// Reports whether encoding/json would decode the property into one of the fields,
+// names are matched case-insensitively like encoding/json does
+func isKnownField(fields []structField, name string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(field.name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type structField struct {
+	// index sequence of the field, through the embedded structs it is promoted from
+	index       []int
+	name        string
+	encodedName []byte
+	omitEmpty   bool
+	nullable    bool
+	// encoded within a JSON string, the `,string` option
+	quoted bool
+	tagged bool
+}
+
+var nullablePkgPath = reflect.TypeOf(Nullable[int]{}).PkgPath()
+var nullableStateIndex = func() int {
+	field, _ := reflect.TypeOf(Nullable[int]{}).FieldByName("state")
+	return field.Index[0]
+}()
+
+// Reports whether the type is an instantiation of Nullable
+func isNullableType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == nullablePkgPath && strings.HasPrefix(t.Name(), "Nullable[")
+}
+
+var structFieldCache sync.Map // map[reflect.Type][]structField
+
+// Collects the JSON encoded fields of a struct type in declaration order, promoting the
+// fields of embedded structs like encoding/json does: a name held by several fields goes
+// to the least nested one, then to the only tagged one, and is dropped when that's ambiguous
+func cachedStructFields(t reflect.Type) []structField {
+	if cached, ok := structFieldCache.Load(t); ok {
+		return cached.([]structField)
+	}
+
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	candidates := []structField{}
+	visited := map[reflect.Type]bool{}
+	for next := []embedded{{typ: t}}; len(next) > 0; {
+		level := next
+		next = nil
+		for _, e := range level {
+			visited[e.typ] = true
+		}
+
+		for _, e := range level {
+			for i := 0; i < e.typ.NumField(); i++ {
+				field := e.typ.Field(i)
+				fieldType := field.Type
+				if fieldType.Name() == "" && fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+				if field.PkgPath != "" && (!field.Anonymous || fieldType.Kind() != reflect.Struct) {
+					// unexported, the exported fields of unexported embedded structs are still promoted
+					continue
+				}
+
+				tag := field.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := append(e.index[:len(e.index):len(e.index)], i)
+				if name == "" && field.Anonymous && fieldType.Kind() == reflect.Struct {
+					if !visited[fieldType] {
+						next = append(next, embedded{typ: fieldType, index: index})
+					}
+					continue
+				}
+
+				tagged := name != ""
+				if !tagged {
+					name = field.Name
+				}
+				encodedName, _ := json.Marshal(name)
+				candidates = append(candidates, structField{
+					index:       index,
+					name:        name,
+					encodedName: encodedName,
+					omitEmpty:   hasTagOption(opts, "omitempty") || hasTagOption(opts, "omitzero"),
+					nullable:    isNullableType(field.Type),
+					quoted:      hasTagOption(opts, "string") && isQuotableType(fieldType),
+					tagged:      tagged,
+				})
+			}
+		}
+	}
+
+	byName := map[string][]structField{}
+	for _, field := range candidates {
+		byName[field.name] = append(byName[field.name], field)
+	}
+	fields := []structField{}
+	for _, field := range candidates {
+		if dominant, ok := dominantField(byName[field.name]); ok && reflect.DeepEqual(dominant.index, field.index) {
+			fields = append(fields, field)
+		}
+	}
+	// declaration order, embedded fields in place of the struct they are promoted from
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	structFieldCache.Store(t, fields)
+	return fields
+}
+
+// Returns the field holding a name among the fields sharing it
+func dominantField(fields []structField) (structField, bool) {
+	depth := len(fields[0].index)
+	for _, field := range fields {
+		if len(field.index) < depth {
+			depth = len(field.index)
+		}
+	}
+
+	var dominant []structField
+	for _, field := range fields {
+		if len(field.index) == depth {
+			dominant = append(dominant, field)
+		}
+	}
+	if len(dominant) == 1 {
+		return dominant[0], true
+	}
+	var tagged []structField
+	for _, field := range dominant {
+		if field.tagged {
+			tagged = append(tagged, field)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+
+	return structField{}, false
+}
+
+// Reports whether the `,string` option applies to the type, as it does to scalars only.
+// Types marshalling themselves are left as they encode
+func isQuotableType(t reflect.Type) bool {
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+
+	return false
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Returns the field at the index sequence, false if it is promoted through a nil pointer
+func fieldByIndex(val reflect.Value, index []int) (reflect.Value, bool) {
+	for i, fieldIndex := range index {
+		if i > 0 && val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				return reflect.Value{}, false
+			}
+			val = val.Elem()
+		}
+		val = val.Field(fieldIndex)
+	}
+
+	return val, true
+}
+
+func hasTagOption(opts string, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reports whether encoding/json's omitempty would omit the value
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+
+	return false
+}
@@ -0,0 +1,137 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// JSON Merge Patch (RFC 7396) maps directly onto the three Nullable states: an undefined
+// field is left unchanged, a null field is removed and a set field is replaced (objects
+// are merged recursively, arrays are replaced as a whole).
+//
+// A merge patch cannot set a field to an explicit null, applying a null removes the
+// field which leaves the corresponding Nullable undefined.
+
+// CreateMergePatch returns the merge patch document that turns the JSON encoding of
+// original into the JSON encoding of modified
+func CreateMergePatch(original interface{}, modified interface{}) ([]byte, error) {
+	originalDoc, err := toJSONDocument(original)
+	if err != nil {
+		return nil, err
+	}
+	modifiedDoc, err := toJSONDocument(modified)
+	if err != nil {
+		return nil, err
+	}
+
+	originalObj, originalIsObj := originalDoc.(map[string]interface{})
+	modifiedObj, modifiedIsObj := modifiedDoc.(map[string]interface{})
+	if !originalIsObj || !modifiedIsObj {
+		// non-object documents are replaced as a whole
+		return json.Marshal(modifiedDoc)
+	}
+
+	return json.Marshal(diffObjects(originalObj, modifiedObj))
+}
+
+// ApplyMergePatch applies a merge patch document to a JSON document
+func ApplyMergePatch(document []byte, patch []byte) ([]byte, error) {
+	targetDoc, err := decodeJSONDocument(document)
+	if err != nil {
+		return nil, err
+	}
+	patchDoc, err := decodeJSONDocument(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergePatch(targetDoc, patchDoc))
+}
+
+// MergePatchValue applies a merge patch document to the JSON encoding of target, returning
+// the result decoded into a new T. Fields removed by the patch are left undefined
+func MergePatchValue[T any](target T, patch []byte) (T, error) {
+	var result T
+
+	document, err := json.Marshal(target)
+	if err != nil {
+		return result, err
+	}
+	patched, err := ApplyMergePatch(document, patch)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(patched, &result)
+
+	return result, err
+}
+
+func toJSONDocument(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSONDocument(data)
+}
+
+func decodeJSONDocument(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keep numbers exact
+	decoder.UseNumber()
+
+	var doc interface{}
+	err := decoder.Decode(&doc)
+	return doc, err
+}
+
+func diffObjects(original map[string]interface{}, modified map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for key := range original {
+		if _, exists := modified[key]; !exists {
+			patch[key] = nil
+		}
+	}
+
+	for key, modifiedVal := range modified {
+		originalVal, exists := original[key]
+		if !exists {
+			patch[key] = modifiedVal
+			continue
+		}
+
+		originalObj, originalIsObj := originalVal.(map[string]interface{})
+		modifiedObj, modifiedIsObj := modifiedVal.(map[string]interface{})
+		if originalIsObj && modifiedIsObj {
+			if nested := diffObjects(originalObj, modifiedObj); len(nested) > 0 {
+				patch[key] = nested
+			}
+		} else if !reflect.DeepEqual(originalVal, modifiedVal) {
+			patch[key] = modifiedVal
+		}
+	}
+
+	return patch
+}
+
+// The MergePatch algorithm of RFC 7396 section 2
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	for key, patchVal := range patchObj {
+		if patchVal == nil {
+			delete(targetObj, key)
+		} else {
+			targetObj[key] = mergePatch(targetObj[key], patchVal)
+		}
+	}
+
+	return targetObj
+}
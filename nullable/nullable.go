@@ -15,51 +15,90 @@ import (
 // 2. field is explicitly set to `null`
 // 3. field is explicitly set to a value of type T
 //
-// In the event a JSON field should be both nullable and optional, be sure
-// to include the `json:"omitempty"` tag for that field so this generic type will work
-// as intended.
+// The zero value of a Nullable is undefined. Nullable is a plain struct, so setting a
+// value does not allocate and copies of a Nullable never share state (unless T itself
+// is a reference type such as a slice or map).
 //
-// The type uses map[bool]T under the hood rather than a struct
-// as encoding/json's omitempty tag does not function with embedded
-// structs: https://www.sohamkamani.com/golang/omitempty/#values-that-cannot-be-omitted
-// Under the hood states:
-// - map[true]T => Value is set and should be present in the final marshaled JSON
-// - map[false]T => Null is set and should be present in the final marshled JSON
-// - Zero val or nil =>  map means the field is undefined and should *not* be present in the final marshaled JSON
-type Nullable[T any] map[bool]T
+// encoding/json's omitempty tag does not function with structs:
+// https://www.sohamkamani.com/golang/omitempty/#values-that-cannot-be-omitted
+// so structs holding Nullable fields implement MarshalJSON with MarshalStruct to omit
+// undefined fields tagged with omitempty, as every model in `types` does.
+//
+// Breaking change from the previous map[bool]T representation, which encoding/json omitted
+// as an empty map. Migrating:
+// - NewValue, NewNull, Set, SetNull, SetUndefined, Value & InterfaceValue are unchanged
+// - IsNullableInterface is unchanged, it no longer requires reflection for Nullable values
+// - map literals (`Nullable[T]{true: v}`) become NewValue(v), NewNull[T]() or Nullable[T]{}
+// - map access (`n[true]`, `len(n) == 0`) becomes n.Value() & n.IsUndefined()
+// - structs of your own holding Nullable fields no longer omit undefined fields with a
+// plain omitempty tag, they need a MarshalJSON calling MarshalStruct to:
+//
+//	func (p MyPet) MarshalJSON() ([]byte, error) {
+//		type alias MyPet
+//		return nullable.MarshalStruct(alias(p))
+//	}
+type Nullable[T any] struct {
+	value T
+	state state
+}
 type NullableLike interface {
 	IsNull() bool
 	IsUndefined() bool
 	InterfaceValue() (interface{}, error)
 }
 
+type state uint8
+
+const (
+	stateUndefined state = iota
+	stateNull
+	stateValue
+)
+
 // ----- Constructors -----
 
 // Constructor of a `Nullable` with a given value
 func NewValue[T any](t T) Nullable[T] {
-	var n Nullable[T]
-	n.Set(t)
-	return n
+	return Nullable[T]{value: t, state: stateValue}
 }
 
 // Constructor of a `Nullable` with an explict value of null
 func NewNull[T any]() Nullable[T] {
-	var n Nullable[T]
-	n.SetNull()
-	return n
+	return Nullable[T]{state: stateNull}
+}
+
+// Constructor of a `Nullable` from a pointer, a nil pointer is an explicit null
+func FromPtr[T any](p *T) Nullable[T] {
+	if p == nil {
+		return NewNull[T]()
+	}
+	return NewValue(*p)
 }
 
 // ----- Helper ------
 
-func IsNullableInterface(v interface{}) (NullableLike, bool) {
-	val := reflect.ValueOf(v)
+// Applies the function to the value if set, null & undefined are carried over as is
+func Map[T any, U any](n Nullable[T], f func(T) U) Nullable[U] {
+	switch n.state {
+	case stateValue:
+		return NewValue(f(n.value))
+	case stateNull:
+		return NewNull[U]()
+	default:
+		return Nullable[U]{}
+	}
+}
 
-	if val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
-		val = val.Elem()
+func IsNullableInterface(v interface{}) (NullableLike, bool) {
+	if nullableLike, ok := v.(NullableLike); ok {
+		return nullableLike, true
 	}
 
-	if val.Type().Implements(reflect.TypeOf((*NullableLike)(nil)).Elem()) {
-		return val.Interface().(NullableLike), true
+	val := reflect.ValueOf(v)
+	if (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) && !val.IsNil() {
+		if nullableLike, ok := val.Elem().Interface().(NullableLike); ok {
+			return nullableLike, true
+		}
 	}
 
 	return nil, false
@@ -69,29 +108,32 @@ func IsNullableInterface(v interface{}) (NullableLike, bool) {
 
 // Sets an explicit value
 func (n *Nullable[T]) Set(value T) {
-	*n = map[bool]T{true: value}
+	*n = Nullable[T]{value: value, state: stateValue}
 }
 
 // Is the value explicitly null (rather than undefined)
 func (n Nullable[T]) IsNull() bool {
-	_, isNull := n[false]
-	return isNull
+	return n.state == stateNull
 }
 
 // Set the value for marshalling explicitly to `null`
 func (n *Nullable[T]) SetNull() {
-	var zero T
-	*n = map[bool]T{false: zero}
+	*n = Nullable[T]{state: stateNull}
 }
 
 // Is the value not explicitly set to `null` or a value
 func (t Nullable[T]) IsUndefined() bool {
-	return len(t) == 0
+	return t.state == stateUndefined
 }
 
 // Explicitly set the Nullable to undefined
 func (t *Nullable[T]) SetUndefined() {
-	*t = map[bool]T{}
+	*t = Nullable[T]{}
+}
+
+// Is the value undefined, same as IsUndefined
+func (t Nullable[T]) IsZero() bool {
+	return t.IsUndefined()
 }
 
 // Return the underlying value if set. Will return an error if the Nullable is set to `null` explicitly
@@ -103,7 +145,7 @@ func (n Nullable[T]) Value() (T, error) {
 	} else if n.IsUndefined() {
 		return zero, errors.New("value is undefined")
 	}
-	return n[true], nil
+	return n.value, nil
 }
 
 // Return the underlying value if set as an interface{}. Helpful for generic functions where
@@ -117,7 +159,56 @@ func (n Nullable[T]) InterfaceValue() (interface{}, error) {
 		return zero, errors.New("value is undefined")
 	}
 
-	return n[true], nil
+	return n.value, nil
+}
+
+// Is the value explicitly set (neither null nor undefined)
+func (n Nullable[T]) IsSet() bool {
+	return n.state == stateValue
+}
+
+// Return a pointer to a copy of the value if set, nil if null or undefined
+func (n Nullable[T]) Ptr() *T {
+	if n.state != stateValue {
+		return nil
+	}
+	value := n.value
+	return &value
+}
+
+// Return the value if set, otherwise the given default
+func (n Nullable[T]) OrElse(defaultValue T) T {
+	if n.state != stateValue {
+		return defaultValue
+	}
+	return n.value
+}
+
+// Return the value if set, otherwise the zero value of T
+func (n Nullable[T]) OrZero() T {
+	var zero T
+	return n.OrElse(zero)
+}
+
+// Reports whether both Nullables are in the same state and, if set, hold equal values.
+// Values are compared with their own Equal method when they have one, deeply otherwise
+func (n Nullable[T]) Equal(other Nullable[T]) bool {
+	if n.state != other.state {
+		return false
+	}
+	if n.state != stateValue {
+		return true
+	}
+	return DeepEqual(n.value, other.value)
+}
+
+// Return a deep copy of the Nullable, the copy shares no slices, maps or pointers with
+// the original. Values are copied with their own Clone method when they have one
+func (n Nullable[T]) Clone() Nullable[T] {
+	if n.state != stateValue {
+		return n
+	}
+	return NewValue(DeepCopy(n.value))
 }
 
 // ----- encoding/json implementations -----
@@ -128,11 +219,11 @@ func (n Nullable[T]) MarshalJSON() ([]byte, error) {
 		return []byte("null"), nil
 	}
 
-	// if field was unspecified, and thus contains a zero value for n[true] and `omitempty` is set on the field's tags,
-	// `json.Marshal` will automatically omit this field
+	// if field was unspecified it contains a zero value, the containing struct
+	// is responsible for omitting it (see MarshalStruct)
 
 	// otherwise: we have a value, so marshal it
-	return json.Marshal(n[true])
+	return json.Marshal(n.value)
 }
 
 func (t *Nullable[T]) UnmarshalJSON(data []byte) error {
@@ -0,0 +1,167 @@
+package nullable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ----- database/sql implementations -----
+//
+// SQL has no notion of undefined:
+// - scanning SQL NULL produces an explicit null, scanning any other value sets it
+// - both null and undefined are written as SQL NULL, so an undefined Nullable does not
+//   survive a round trip through the database. Leave the column out of the statement
+//   to keep "leave unchanged" semantics
+//
+// Nullable cannot implement driver.Valuer itself as its Value method returns T, use
+// SQL() to pass a Nullable as a query argument:
+//
+//	db.Exec("UPDATE pets SET status = ? WHERE id = ?", pet.Status.SQL(), id)
+//	db.QueryRow("SELECT status FROM pets WHERE id = ?", id).Scan(&pet.Status)
+
+// Implements sql.Scanner
+func (n *Nullable[T]) Scan(src interface{}) error {
+	if src == nil {
+		n.SetNull()
+		return nil
+	}
+
+	var v T
+	if scanner, ok := interface{}(&v).(sql.Scanner); ok {
+		if err := scanner.Scan(src); err != nil {
+			return err
+		}
+		n.Set(v)
+		return nil
+	}
+
+	if err := scanValue(src, reflect.ValueOf(&v).Elem()); err != nil {
+		return fmt.Errorf("nullable: cannot scan %T into %T: %w", src, v, err)
+	}
+	n.Set(v)
+	return nil
+}
+
+// Returns the value as a database/sql driver value, nil for null & undefined. Set values
+// implementing driver.Valuer are converted with it, composite values are written as JSON
+func (n Nullable[T]) DriverValue() (driver.Value, error) {
+	if n.state != stateValue {
+		return nil, nil
+	}
+
+	value := reflect.ValueOf(&n.value).Elem()
+	switch value.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Map, reflect.Array:
+		if _, isValuer := interface{}(n.value).(driver.Valuer); !isValuer && !isDriverStruct(value) {
+			return formatText(value)
+		}
+	}
+
+	return driver.DefaultParameterConverter.ConvertValue(n.value)
+}
+
+// Returns a driver.Valuer passing the Nullable as a query argument, see DriverValue
+func (n Nullable[T]) SQL() driver.Valuer {
+	return sqlValuer[T]{n}
+}
+
+type sqlValuer[T any] struct {
+	nullable Nullable[T]
+}
+
+func (v sqlValuer[T]) Value() (driver.Value, error) {
+	return v.nullable.DriverValue()
+}
+
+// Structs & slices drivers natively support
+func isDriverStruct(v reflect.Value) bool {
+	switch v.Interface().(type) {
+	case time.Time, []byte:
+		return true
+	}
+	return false
+}
+
+// Converts a driver value into the settable target
+func scanValue(src interface{}, target reflect.Value) error {
+	switch srcVal := src.(type) {
+	case []byte:
+		if target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8 {
+			target.SetBytes(append([]byte{}, srcVal...))
+			return nil
+		}
+		return parseText(string(srcVal), target)
+	case string:
+		return parseText(srcVal, target)
+	case time.Time:
+		if reflect.TypeOf(srcVal).AssignableTo(target.Type()) {
+			target.Set(reflect.ValueOf(srcVal))
+			return nil
+		}
+		if target.Kind() == reflect.String {
+			target.SetString(srcVal.Format(time.RFC3339Nano))
+			return nil
+		}
+	case int64:
+		switch target.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if target.OverflowInt(srcVal) {
+				return fmt.Errorf("value %d overflows %s", srcVal, target.Type())
+			}
+			target.SetInt(srcVal)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if srcVal < 0 || target.OverflowUint(uint64(srcVal)) {
+				return fmt.Errorf("value %d overflows %s", srcVal, target.Type())
+			}
+			target.SetUint(uint64(srcVal))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(float64(srcVal))
+			return nil
+		case reflect.Bool:
+			target.SetBool(srcVal != 0)
+			return nil
+		case reflect.String:
+			target.SetString(strconv.FormatInt(srcVal, 10))
+			return nil
+		}
+	case float64:
+		switch target.Kind() {
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(srcVal)
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if srcVal != math.Trunc(srcVal) || target.OverflowInt(int64(srcVal)) {
+				return fmt.Errorf("value %v does not fit %s", srcVal, target.Type())
+			}
+			target.SetInt(int64(srcVal))
+			return nil
+		case reflect.String:
+			target.SetString(strconv.FormatFloat(srcVal, 'f', -1, 64))
+			return nil
+		}
+	case bool:
+		switch target.Kind() {
+		case reflect.Bool:
+			target.SetBool(srcVal)
+			return nil
+		case reflect.String:
+			target.SetString(strconv.FormatBool(srcVal))
+			return nil
+		}
+	}
+
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Type().ConvertibleTo(target.Type()) {
+		target.Set(srcValue.Convert(target.Type()))
+		return nil
+	}
+
+	return fmt.Errorf("unsupported conversion")
+}
@@ -0,0 +1,109 @@
+package nullable
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// ----- encoding.TextMarshaler / TextUnmarshaler implementations -----
+//
+// Text has no way to express the absence of a value, so:
+// - a set value is written as its text: strings & string enums as is, numbers & bools
+//   with strconv, encoding.TextMarshaler values with MarshalText, everything else as JSON
+// - null and undefined are both written as empty text
+// - empty text is read as null, a loader that never calls UnmarshalText (e.g. an unset
+//   environment variable) leaves the Nullable undefined
+
+func (n Nullable[T]) MarshalText() ([]byte, error) {
+	if n.state != stateValue {
+		return []byte{}, nil
+	}
+
+	return formatText(reflect.ValueOf(&n.value).Elem())
+}
+
+func (n *Nullable[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		n.SetNull()
+		return nil
+	}
+
+	var v T
+	if err := parseText(string(text), reflect.ValueOf(&v).Elem()); err != nil {
+		return err
+	}
+	n.Set(v)
+	return nil
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Formats a value as text
+func formatText(v reflect.Value) ([]byte, error) {
+	if v.Type().Implements(textMarshalerType) {
+		return v.Interface().(encoding.TextMarshaler).MarshalText()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return []byte(v.String()), nil
+	case reflect.Bool:
+		return []byte(strconv.FormatBool(v.Bool())), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []byte(strconv.FormatInt(v.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []byte(strconv.FormatUint(v.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		return []byte(strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())), nil
+	}
+
+	return json.Marshal(v.Interface())
+}
+
+// Parses text into the settable value, the inverse of formatText
+func parseText(text string, target reflect.Value) error {
+	if target.Addr().Type().Implements(textUnmarshalerType) {
+		return target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(text, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(f)
+	case reflect.Struct, reflect.Slice, reflect.Map, reflect.Array:
+		if err := json.Unmarshal([]byte(text), target.Addr().Interface()); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("cannot parse text into %s", target.Type())
+	}
+
+	return nil
+}
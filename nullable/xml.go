@@ -0,0 +1,172 @@
+package nullable
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ----- xml.Marshaler / xml.Unmarshaler implementations -----
+//
+// XML elements are either present or absent, so:
+// - an undefined Nullable writes no element and a missing element leaves it undefined
+// - null is written as an empty element carrying `xsi:nil="true"` and read back as null
+// - a Nullable slice is written as one element per item, like encoding/xml does for slices,
+//   and every element read is appended to it
+
+// Namespace of the `xsi:nil` attribute marking null elements
+const XMLSchemaInstance = "http://www.w3.org/2001/XMLSchema-instance"
+
+func (n Nullable[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	switch n.state {
+	case stateUndefined:
+		return nil
+	case stateNull:
+		// spelled out with the conventional xsi prefix, encoding/xml would invent its own.
+		// The attributes are copied, appending could write to the caller's array
+		start.Attr = append(start.Attr[:len(start.Attr):len(start.Attr)],
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: XMLSchemaInstance},
+			xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+		)
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+
+	return e.EncodeElement(n.value, start)
+}
+
+func (n *Nullable[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == XMLSchemaInstance && attr.Name.Local == "nil" && (attr.Value == "true" || attr.Value == "1") {
+			n.SetNull()
+			return d.Skip()
+		}
+	}
+
+	target := reflect.ValueOf(&n.value).Elem()
+	if target.Kind() == reflect.Slice && target.Type().Elem().Kind() != reflect.Uint8 {
+		// every item of a list arrives as an element of its own
+		item := reflect.New(target.Type().Elem())
+		if err := d.DecodeElement(item.Interface(), &start); err != nil {
+			return err
+		}
+		if n.state != stateValue {
+			target.Set(reflect.MakeSlice(target.Type(), 0, 1))
+		}
+		target.Set(reflect.Append(target, item.Elem()))
+		n.state = stateValue
+		return nil
+	}
+
+	var v T
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	n.Set(v)
+	return nil
+}
+
+// MarshalXMLStruct writes a struct as the element `start` the way encoding/xml does, while
+// omitting undefined Nullable fields together with their `wrapper>item` parent elements.
+// Only element fields are supported, `attr`, `chardata`, `innerxml` & `comment` fields are
+// not. It is meant to be called from a struct's MarshalXML on a method-less alias:
+//
+//	func (p Pet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+//		type alias Pet
+//		return nullable.MarshalXMLStruct(e, start, alias(p))
+//	}
+func MarshalXMLStruct(e *xml.Encoder, start xml.StartElement, v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("nullable.MarshalXMLStruct expects a struct, received %s", val.Kind())
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, field := range cachedXMLFields(val.Type()) {
+		fieldVal := val.Field(field.index)
+		if field.nullable {
+			fieldState := state(fieldVal.Field(nullableStateIndex).Uint())
+			// a wrapper element has no way to carry null
+			if fieldState == stateUndefined || fieldState == stateNull && len(field.parents) > 0 {
+				continue
+			}
+		} else if field.omitEmpty && isEmptyJSONValue(fieldVal) {
+			continue
+		}
+
+		for _, parent := range field.parents {
+			if err := e.EncodeToken(xml.StartElement{Name: xml.Name{Local: parent}}); err != nil {
+				return err
+			}
+		}
+		if err := e.EncodeElement(fieldVal.Interface(), xml.StartElement{Name: xml.Name{Local: field.name}}); err != nil {
+			return err
+		}
+		for i := len(field.parents) - 1; i >= 0; i-- {
+			if err := e.EncodeToken(xml.EndElement{Name: xml.Name{Local: field.parents[i]}}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+type xmlField struct {
+	index     int
+	name      string
+	parents   []string
+	omitEmpty bool
+	nullable  bool
+}
+
+var xmlFieldCache sync.Map // map[reflect.Type][]xmlField
+
+// Collects the XML encoded fields of a struct type in declaration order
+func cachedXMLFields(t reflect.Type) []xmlField {
+	if cached, ok := xmlFieldCache.Load(t); ok {
+		return cached.([]xmlField)
+	}
+
+	fields := []xmlField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Name == "XMLName" {
+			// unexported or the element name
+			continue
+		}
+
+		tag := field.Tag.Get("xml")
+		if tag == "-" {
+			continue
+		}
+		path, opts, _ := strings.Cut(tag, ",")
+		if path == "" {
+			path = field.Name
+		}
+		names := strings.Split(path, ">")
+
+		fields = append(fields, xmlField{
+			index:     i,
+			name:      names[len(names)-1],
+			parents:   names[:len(names)-1],
+			omitEmpty: hasTagOption(opts, "omitempty"),
+			nullable:  isNullableType(field.Type),
+		})
+	}
+
+	xmlFieldCache.Store(t, fields)
+	return fields
+}
@@ -1,11 +1,8 @@
 package pet
 
 import (
-	bytes "bytes"
-	json "encoding/json"
 	io "io"
 	http "net/http"
-	os "os"
 	sdkcore "pets_go/core"
 	types "pets_go/types"
 )
@@ -30,6 +27,13 @@ func NewClient(coreClient *sdkcore.CoreClient) *Client {
 //
 // DELETE /pet/{petId}
 func (c *Client) Delete(request DeleteRequest, reqModifiers ...RequestModifier) (http.Response, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return http.Response{}, err
+		}
+	}
+
 	// URL formatting
 	targetUrl, err := c.coreClient.BuildURL("/pet/" + sdkcore.FmtStringParam(request.PetId))
 	if err != nil {
@@ -77,6 +81,13 @@ func (c *Client) Delete(request DeleteRequest, reqModifiers ...RequestModifier)
 //
 // GET /pet/findByStatus
 func (c *Client) FindByStatus(request FindByStatusRequest, reqModifiers ...RequestModifier) (http.Response, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return http.Response{}, err
+		}
+	}
+
 	// URL formatting
 	targetUrl, err := c.coreClient.BuildURL("/pet/" + "findByStatus")
 	if err != nil {
@@ -85,8 +96,12 @@ func (c *Client) FindByStatus(request FindByStatusRequest, reqModifiers ...Reque
 
 	// Query params
 	params := targetUrl.Query()
-	sdkcore.AddQueryParam(params, "status", request.Status, "form", true)
-	targetUrl.RawQuery = params.Encode()
+	if len(request.Statuses) == 0 {
+		sdkcore.AddQueryParam(params, "status", request.Status, "form", true)
+	} else {
+		sdkcore.AddQueryParam(params, "status", request.allStatuses(), "form", request.StatusEncoding.Explode())
+	}
+	targetUrl.RawQuery = sdkcore.CanonicalQueryString(params)
 
 	// Init request
 	req, err := http.NewRequest("GET", targetUrl.String(), nil)
@@ -96,6 +111,7 @@ func (c *Client) FindByStatus(request FindByStatusRequest, reqModifiers ...Reque
 
 	// Add headers
 	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
 
 	// Add auth
 	err = c.coreClient.AddAuth(req, "api_key")
@@ -123,12 +139,92 @@ func (c *Client) FindByStatus(request FindByStatusRequest, reqModifiers ...Reque
 
 }
 
+// Finds Pets by tags.
+//
+// Multiple tags can be provided with comma separated strings. Use tag1, tag2, tag3 for testing.
+//
+// GET /pet/findByTags
+func (c *Client) FindByTags(request FindByTagsRequest, reqModifiers ...RequestModifier) ([]types.Pet, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// URL formatting
+	targetUrl, err := c.coreClient.BuildURL("/pet/" + "findByTags")
+	if err != nil {
+		return nil, err
+	}
+
+	// Query params
+	params := targetUrl.Query()
+	if len(request.Tags) > 0 {
+		sdkcore.AddQueryParam(params, "tags", request.Tags, "form", request.TagsEncoding.Explode())
+	}
+	targetUrl.RawQuery = sdkcore.CanonicalQueryString(params)
+
+	// Init request
+	req, err := http.NewRequest("GET", targetUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
+
+	// Add auth
+	err = c.coreClient.AddAuth(req, "api_key")
+	if err != nil {
+		return nil, err
+	}
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return nil, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return nil, sdkcore.NewApiError(*req, *resp)
+	}
+
+	// Handle response
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var bodyData []types.Pet
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData)
+	if err != nil {
+		return nil, err
+	}
+	return bodyData, nil
+
+}
+
 // Find pet by ID.
 //
 // Returns a single pet.
 //
 // GET /pet/{petId}
 func (c *Client) Get(request GetRequest, reqModifiers ...RequestModifier) (http.Response, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return http.Response{}, err
+		}
+	}
+
 	// URL formatting
 	targetUrl, err := c.coreClient.BuildURL("/pet/" + sdkcore.FmtStringParam(request.PetId))
 	if err != nil {
@@ -143,6 +239,7 @@ func (c *Client) Get(request GetRequest, reqModifiers ...RequestModifier) (http.
 
 	// Add headers
 	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
 
 	// Add auth
 	err = c.coreClient.AddAuth(req, "api_key")
@@ -176,6 +273,13 @@ func (c *Client) Get(request GetRequest, reqModifiers ...RequestModifier) (http.
 //
 // POST /pet
 func (c *Client) Create(request CreateRequest, reqModifiers ...RequestModifier) (http.Response, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return http.Response{}, err
+		}
+	}
+
 	// URL formatting
 	targetUrl, err := c.coreClient.BuildURL("/pet")
 	if err != nil {
@@ -183,19 +287,35 @@ func (c *Client) Create(request CreateRequest, reqModifiers ...RequestModifier)
 	}
 
 	// Prep body
-	reqBodyBuf := &bytes.Buffer{}
-	reqBody, err := json.Marshal(types.Pet{
-		Category:  request.Category,
-		Id:        request.Id,
-		Status:    request.Status,
-		Tags:      request.Tags,
-		Name:      request.Name,
-		PhotoUrls: request.PhotoUrls,
-	})
+	contentType := request.ContentType
+	if contentType == "" {
+		contentType = sdkcore.ContentTypeJSON
+	}
+	reqBodyBuf, contentType, err := c.coreClient.EncodeBody(
+		request.ToPet(),
+		contentType,
+		sdkcore.EncodeOptions{
+			FormStyle: map[string]string{
+				"category":  "form",
+				"id":        "form",
+				"name":      "form",
+				"photoUrls": "form",
+				"status":    "form",
+				"tags":      "form",
+			},
+			FormExplode: map[string]bool{
+				"category":  true,
+				"id":        true,
+				"name":      true,
+				"photoUrls": true,
+				"status":    true,
+				"tags":      true,
+			},
+		},
+	)
 	if err != nil {
 		return http.Response{}, err
 	}
-	reqBodyBuf = bytes.NewBuffer([]byte(reqBody))
 
 	// Init request
 	req, err := http.NewRequest("POST", targetUrl.String(), reqBodyBuf)
@@ -205,7 +325,8 @@ func (c *Client) Create(request CreateRequest, reqModifiers ...RequestModifier)
 
 	// Add headers
 	req.Header.Add("x-sideko-sdk-language", "Go")
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
+	req.Header.Add("Content-Type", contentType)
 
 	// Add auth
 	err = c.coreClient.AddAuth(req, "api_key")
@@ -239,6 +360,22 @@ func (c *Client) Create(request CreateRequest, reqModifiers ...RequestModifier)
 //
 // POST /pet/{petId}/uploadImage
 func (c *Client) UploadImage(request UploadImageRequest, reqModifiers ...RequestModifier) (types.ApiResponse, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return types.ApiResponse{}, err
+		}
+	}
+
+	// Prepare image
+	if request.ImageOptions != nil {
+		data, err := PrepareImage(request.Data, *request.ImageOptions)
+		if err != nil {
+			return types.ApiResponse{}, err
+		}
+		request.Data = data
+	}
+
 	// URL formatting
 	targetUrl, err := c.coreClient.BuildURL("/pet/" + sdkcore.FmtStringParam(request.PetId) + "/uploadImage")
 	if err != nil {
@@ -248,30 +385,34 @@ func (c *Client) UploadImage(request UploadImageRequest, reqModifiers ...Request
 	// Query params
 	params := targetUrl.Query()
 	sdkcore.AddQueryParam(params, "additionalMetadata", request.AdditionalMetadata, "form", true)
-	targetUrl.RawQuery = params.Encode()
-
-	// Prep body
-	reqBodyBuf := &os.File{}
-	reqBodyBuf = &request.Data
+	targetUrl.RawQuery = sdkcore.CanonicalQueryString(params)
 
 	// Init request
-	req, err := http.NewRequest("POST", targetUrl.String(), reqBodyBuf)
+	req, err := http.NewRequest("POST", targetUrl.String(), nil)
 	if err != nil {
 		return types.ApiResponse{}, err
 	}
 
+	// Prep body
+	if err := sdkcore.SetFileBody(req, request.Data); err != nil {
+		return types.ApiResponse{}, err
+	}
+
 	// Add headers
 	req.Header.Add("x-sideko-sdk-language", "Go")
-	req.Header.Add("Content-Type", "application/octet-stream")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON))
 
 	// Add auth
 	err = c.coreClient.AddAuth(req, "api_key")
 	if err != nil {
+		// the file is open, it is closed by the transport once the request is sent
+		req.Body.Close()
 		return types.ApiResponse{}, err
 	}
 
 	// Add base client & request level modifiers
 	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		req.Body.Close()
 		return types.ApiResponse{}, err
 	}
 
@@ -293,7 +434,7 @@ func (c *Client) UploadImage(request UploadImageRequest, reqModifiers ...Request
 		return types.ApiResponse{}, err
 	}
 	var bodyData types.ApiResponse
-	err = json.Unmarshal(body, &bodyData)
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData)
 	if err != nil {
 		return types.ApiResponse{}, err
 	}
@@ -307,6 +448,13 @@ func (c *Client) UploadImage(request UploadImageRequest, reqModifiers ...Request
 //
 // PUT /pet
 func (c *Client) Update(request UpdateRequest, reqModifiers ...RequestModifier) (http.Response, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return http.Response{}, err
+		}
+	}
+
 	// URL formatting
 	targetUrl, err := c.coreClient.BuildURL("/pet")
 	if err != nil {
@@ -314,19 +462,35 @@ func (c *Client) Update(request UpdateRequest, reqModifiers ...RequestModifier)
 	}
 
 	// Prep body
-	reqBodyBuf := &bytes.Buffer{}
-	reqBody, err := json.Marshal(types.Pet{
-		Category:  request.Category,
-		Id:        request.Id,
-		Status:    request.Status,
-		Tags:      request.Tags,
-		Name:      request.Name,
-		PhotoUrls: request.PhotoUrls,
-	})
+	contentType := request.ContentType
+	if contentType == "" {
+		contentType = sdkcore.ContentTypeJSON
+	}
+	reqBodyBuf, contentType, err := c.coreClient.EncodeBody(
+		request.ToPet(),
+		contentType,
+		sdkcore.EncodeOptions{
+			FormStyle: map[string]string{
+				"category":  "form",
+				"id":        "form",
+				"name":      "form",
+				"photoUrls": "form",
+				"status":    "form",
+				"tags":      "form",
+			},
+			FormExplode: map[string]bool{
+				"category":  true,
+				"id":        true,
+				"name":      true,
+				"photoUrls": true,
+				"status":    true,
+				"tags":      true,
+			},
+		},
+	)
 	if err != nil {
 		return http.Response{}, err
 	}
-	reqBodyBuf = bytes.NewBuffer([]byte(reqBody))
 
 	// Init request
 	req, err := http.NewRequest("PUT", targetUrl.String(), reqBodyBuf)
@@ -336,7 +500,8 @@ func (c *Client) Update(request UpdateRequest, reqModifiers ...RequestModifier)
 
 	// Add headers
 	req.Header.Add("x-sideko-sdk-language", "Go")
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
+	req.Header.Add("Content-Type", contentType)
 
 	// Add auth
 	err = c.coreClient.AddAuth(req, "api_key")
@@ -363,3 +528,75 @@ func (c *Client) Update(request UpdateRequest, reqModifiers ...RequestModifier)
 	return *resp, nil
 
 }
+
+// Updates a pet in the store with form data.
+//
+// Updates a pet resource based on the form data.
+//
+// POST /pet/{petId}
+func (c *Client) UpdateWithForm(request UpdateWithFormRequest, reqModifiers ...RequestModifier) (types.Pet, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return types.Pet{}, err
+		}
+	}
+
+	// URL formatting
+	targetUrl, err := c.coreClient.BuildURL("/pet/" + sdkcore.FmtStringParam(request.PetId))
+	if err != nil {
+		return types.Pet{}, err
+	}
+
+	// Query params
+	params := targetUrl.Query()
+	sdkcore.AddQueryParam(params, "name", request.Name, "form", true)
+	sdkcore.AddQueryParam(params, "status", request.Status, "form", true)
+	targetUrl.RawQuery = sdkcore.CanonicalQueryString(params)
+
+	// Init request
+	req, err := http.NewRequest("POST", targetUrl.String(), nil)
+	if err != nil {
+		return types.Pet{}, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
+
+	// Add auth
+	err = c.coreClient.AddAuth(req, "api_key")
+	if err != nil {
+		return types.Pet{}, err
+	}
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return types.Pet{}, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return types.Pet{}, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return types.Pet{}, sdkcore.NewApiError(*req, *resp)
+	}
+
+	// Handle response
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return types.Pet{}, err
+	}
+	var bodyData types.Pet
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData)
+	if err != nil {
+		return types.Pet{}, err
+	}
+	return bodyData, nil
+
+}
@@ -0,0 +1,61 @@
+package pet
+
+import (
+	types "pets_go/types"
+)
+
+// Builds a CreateRequest holding every field of the pet
+func CreateRequestFromPet(pet types.Pet) CreateRequest {
+	return CreateRequest{
+		Category:  pet.Category,
+		Id:        pet.Id,
+		Name:      pet.Name,
+		PhotoUrls: pet.PhotoUrls,
+		Status:    pet.Status,
+		Tags:      pet.Tags,
+
+		AdditionalProperties: pet.AdditionalProperties,
+	}
+}
+
+// Returns the pet described by the request
+func (r CreateRequest) ToPet() types.Pet {
+	return types.Pet{
+		Category:  r.Category,
+		Id:        r.Id,
+		Name:      r.Name,
+		PhotoUrls: r.PhotoUrls,
+		Status:    r.Status,
+		Tags:      r.Tags,
+
+		AdditionalProperties: r.AdditionalProperties,
+	}
+}
+
+// Builds an UpdateRequest holding every field of the pet
+func UpdateRequestFromPet(pet types.Pet) UpdateRequest {
+	return UpdateRequest{
+		Category:  pet.Category,
+		Id:        pet.Id,
+		Name:      pet.Name,
+		PhotoUrls: pet.PhotoUrls,
+		Status:    pet.Status,
+		Tags:      pet.Tags,
+
+		AdditionalProperties: pet.AdditionalProperties,
+	}
+}
+
+// Returns the pet described by the request
+func (r UpdateRequest) ToPet() types.Pet {
+	return types.Pet{
+		Category:  r.Category,
+		Id:        r.Id,
+		Name:      r.Name,
+		PhotoUrls: r.PhotoUrls,
+		Status:    r.Status,
+		Tags:      r.Tags,
+
+		AdditionalProperties: r.AdditionalProperties,
+	}
+}
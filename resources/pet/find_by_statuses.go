@@ -0,0 +1,168 @@
+package pet
+
+import (
+	context "context"
+	fmt "fmt"
+	io "io"
+	http "net/http"
+	types "pets_go/types"
+	sync "sync"
+)
+
+// FindByStatusesOptions
+type FindByStatusesOptions struct {
+	// Query each status with a concurrent call of its own and merge the results, the
+	// remaining calls are cancelled once one fails
+	FanOut bool
+}
+
+// Lists Status followed by Statuses, without duplicates
+func (r FindByStatusRequest) allStatuses() []types.PetFindByStatusStatusEnum {
+	statuses := []types.PetFindByStatusStatusEnum{}
+	seen := map[types.PetFindByStatusStatusEnum]bool{}
+	if status, err := r.Status.Value(); err == nil {
+		statuses = append(statuses, status)
+		seen[status] = true
+	}
+	for _, status := range r.Statuses {
+		if !seen[status] {
+			statuses = append(statuses, status)
+			seen[status] = true
+		}
+	}
+
+	return statuses
+}
+
+// Finds Pets having any of the statuses.
+//
+// Queries every status of the request at once, or each status with a concurrent call
+// of its own when options.FanOut is set, and merges the decoded results. Pets are returned in
+// the order of their first occurrence, de-duplicated by ID, pets without an ID are all kept.
+//
+// GET /pet/findByStatus
+func (c *Client) FindByStatuses(request FindByStatusRequest, options FindByStatusesOptions, reqModifiers ...RequestModifier) ([]types.Pet, error) {
+	statuses := request.allStatuses()
+	if !options.FanOut || len(statuses) < 2 {
+		pets, err := c.findPets(request, reqModifiers)
+		if err != nil {
+			return nil, err
+		}
+		return mergePets(pets), nil
+	}
+
+	// bound last so the calls are cancelled whatever context the modifiers set
+	group := &fanOutGroup{}
+	defer group.cancel()
+	reqModifiers = append(reqModifiers[:len(reqModifiers):len(reqModifiers)], group.bind)
+
+	results := make([][]types.Pet, len(statuses))
+	wg := sync.WaitGroup{}
+	for i, status := range statuses {
+		wg.Add(1)
+		go func(i int, status types.PetFindByStatusStatusEnum) {
+			defer wg.Done()
+			pets, err := c.findPets(FindByStatusRequest{Statuses: []types.PetFindByStatusStatusEnum{status}}, reqModifiers)
+			if err != nil {
+				group.fail(fmt.Errorf("finding %s pets: %w", status, err))
+				return
+			}
+			results[i] = pets
+		}(i, status)
+	}
+	wg.Wait()
+
+	if err := group.err; err != nil {
+		return nil, err
+	}
+	return mergePets(results...), nil
+}
+
+// Finds every pet, whatever its status.
+//
+// Queries each status concurrently, see FindByStatuses.
+//
+// GET /pet/findByStatus
+func (c *Client) FindAll(reqModifiers ...RequestModifier) ([]types.Pet, error) {
+	return c.FindByStatuses(FindByStatusRequest{Statuses: types.PetFindByStatusStatusEnumValues()}, FindByStatusesOptions{FanOut: true}, reqModifiers...)
+}
+
+// fanOutGroup cancels the calls of a fan out once one of them fails, keeping the
+// first error rather than the cancellations it causes
+type fanOutGroup struct {
+	mu        sync.Mutex
+	cancels   []context.CancelFunc
+	cancelled bool
+	err       error
+}
+
+// Binds the request to a context cancelled with the group
+func (g *fanOutGroup) bind(req *http.Request) error {
+	ctx, cancel := context.WithCancel(req.Context())
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cancelled {
+		cancel()
+	}
+	g.cancels = append(g.cancels, cancel)
+	*req = *req.WithContext(ctx)
+	return nil
+}
+
+func (g *fanOutGroup) fail(err error) {
+	g.mu.Lock()
+	if g.err == nil {
+		g.err = err
+	}
+	g.mu.Unlock()
+	g.cancel()
+}
+
+func (g *fanOutGroup) cancel() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cancelled = true
+	for _, cancel := range g.cancels {
+		cancel()
+	}
+	g.cancels = nil
+}
+
+// Fetches and decodes the results of a FindByStatus call
+func (c *Client) findPets(request FindByStatusRequest, reqModifiers []RequestModifier) ([]types.Pet, error) {
+	resp, err := c.FindByStatus(request, reqModifiers...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var bodyData []types.Pet
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData)
+	if err != nil {
+		return nil, err
+	}
+	return bodyData, nil
+}
+
+// Concatenates the lists, keeping the first pet of each ID
+func mergePets(lists ...[]types.Pet) []types.Pet {
+	merged := []types.Pet{}
+	seen := map[int]bool{}
+	for _, pets := range lists {
+		for _, pet := range pets {
+			if id, err := pet.Id.Value(); err == nil {
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+			}
+			merged = append(merged, pet)
+		}
+	}
+
+	return merged
+}
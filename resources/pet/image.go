@@ -0,0 +1,285 @@
+package pet
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	image "image"
+	color "image/color"
+	gif "image/gif"
+	jpeg "image/jpeg"
+	png "image/png"
+	io "io"
+	path "path"
+	sdkcore "pets_go/core"
+	strings "strings"
+)
+
+// Image formats detected by PrepareImage
+const (
+	ImageFormatJPEG = "jpeg"
+	ImageFormatPNG  = "png"
+	ImageFormatGIF  = "gif"
+)
+
+// ImageOptions configures the validation and normalization of an image before
+// it is uploaded. Zero values disable the corresponding check
+type ImageOptions struct {
+	// Maximum size of the uploaded image in bytes
+	MaxBytes int64
+	// Maximum width of the image in pixels
+	MaxWidth int
+	// Maximum height of the image in pixels
+	MaxHeight int
+	// Accepted formats (ImageFormatJPEG, ImageFormatPNG, ImageFormatGIF), all when empty
+	AllowedFormats []string
+	// Scale images exceeding MaxWidth or MaxHeight down to fit rather than rejecting them
+	Downscale bool
+	// Re-encode the image, dropping EXIF and other metadata the encoder doesn't write
+	StripMetadata bool
+	// Format to re-encode the image to, the detected format when empty
+	Format string
+	// JPEG quality used when re-encoding, jpeg.DefaultQuality when zero
+	JPEGQuality int
+	// Maximum width*height of an image that is decoded to be re-encoded,
+	// DefaultImageMaxPixels when zero. Decoding allocates memory in proportion to the
+	// dimensions the image declares, whatever the size of its file
+	MaxPixels int64
+}
+
+// Pixels an image may have to be decoded unless ImageOptions.MaxPixels is set, about
+// 200MB once decoded as RGBA
+const DefaultImageMaxPixels = 50_000_000
+
+// Kinds of ImageValidationError
+const (
+	ImageErrorDecode     = "decode"
+	ImageErrorFormat     = "format"
+	ImageErrorSize       = "size"
+	ImageErrorDimensions = "dimensions"
+)
+
+// ImageValidationError is returned when an image fails the checks of its ImageOptions
+type ImageValidationError struct {
+	// One of ImageErrorDecode, ImageErrorFormat, ImageErrorSize or ImageErrorDimensions
+	Kind    string
+	Format  string
+	Width   int
+	Height  int
+	Size    int64
+	Message string
+}
+
+func (e ImageValidationError) Error() string {
+	return fmt.Sprintf("invalid image (%s): %s", e.Kind, e.Message)
+}
+
+// Validates and normalizes an image according to the options, returning the file that
+// should be uploaded with its Content-Type set to the detected or re-encoded format.
+// The whole image is read into memory to be decoded
+func PrepareImage(file sdkcore.File, options ImageOptions) (sdkcore.File, error) {
+	content, err := file.Open()
+	if err != nil {
+		return sdkcore.File{}, err
+	}
+	defer content.Close()
+
+	// read at most one byte beyond the limit to detect oversized images without
+	// buffering all of them, images may shrink when re-encoded so only fail early
+	// when they won't be
+	reader := io.Reader(content)
+	reencode := options.StripMetadata || options.Downscale || options.Format != ""
+	if options.MaxBytes > 0 && !reencode {
+		reader = io.LimitReader(content, options.MaxBytes+1)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return sdkcore.File{}, err
+	}
+	if options.MaxBytes > 0 && !reencode && int64(len(data)) > options.MaxBytes {
+		return sdkcore.File{}, ImageValidationError{
+			Kind:    ImageErrorSize,
+			Size:    int64(len(data)),
+			Message: fmt.Sprintf("image exceeds %d bytes", options.MaxBytes),
+		}
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return sdkcore.File{}, ImageValidationError{Kind: ImageErrorDecode, Message: err.Error()}
+	}
+	if !imageFormatAllowed(format, options.AllowedFormats) {
+		return sdkcore.File{}, ImageValidationError{
+			Kind:    ImageErrorFormat,
+			Format:  format,
+			Width:   config.Width,
+			Height:  config.Height,
+			Message: fmt.Sprintf("format %s is not one of %s", format, strings.Join(options.AllowedFormats, ", ")),
+		}
+	}
+
+	width, height := fitImage(config.Width, config.Height, options.MaxWidth, options.MaxHeight)
+	if (width != config.Width || height != config.Height) && !options.Downscale {
+		return sdkcore.File{}, ImageValidationError{
+			Kind:    ImageErrorDimensions,
+			Format:  format,
+			Width:   config.Width,
+			Height:  config.Height,
+			Message: fmt.Sprintf("%dx%d exceeds %dx%d", config.Width, config.Height, options.MaxWidth, options.MaxHeight),
+		}
+	}
+
+	outFormat := format
+	if options.Format != "" {
+		outFormat = options.Format
+	}
+	if reencode && (width != config.Width || height != config.Height || options.StripMetadata || outFormat != format) {
+		maxPixels := options.MaxPixels
+		if maxPixels <= 0 {
+			maxPixels = DefaultImageMaxPixels
+		}
+		if pixels := int64(config.Width) * int64(config.Height); pixels > maxPixels {
+			return sdkcore.File{}, ImageValidationError{
+				Kind:    ImageErrorDimensions,
+				Format:  format,
+				Width:   config.Width,
+				Height:  config.Height,
+				Message: fmt.Sprintf("%dx%d exceeds %d pixels", config.Width, config.Height, maxPixels),
+			}
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return sdkcore.File{}, ImageValidationError{Kind: ImageErrorDecode, Format: format, Message: err.Error()}
+		}
+		if width != config.Width || height != config.Height {
+			img = downscaleImage(img, width, height)
+		}
+		if data, err = encodeImage(img, outFormat, options.JPEGQuality); err != nil {
+			return sdkcore.File{}, err
+		}
+	}
+
+	if options.MaxBytes > 0 && int64(len(data)) > options.MaxBytes {
+		return sdkcore.File{}, ImageValidationError{
+			Kind:    ImageErrorSize,
+			Format:  outFormat,
+			Width:   width,
+			Height:  height,
+			Size:    int64(len(data)),
+			Message: fmt.Sprintf("image exceeds %d bytes", options.MaxBytes),
+		}
+	}
+
+	filename := file.Filename
+	if filename != "" && outFormat != format {
+		filename = strings.TrimSuffix(filename, path.Ext(filename)) + "." + outFormat
+	}
+	prepared := sdkcore.NewFileFromBytes(filename, data)
+	prepared.ContentType = "image/" + outFormat
+
+	return prepared, nil
+}
+
+func imageFormatAllowed(format string, allowed []string) bool {
+	if format != ImageFormatJPEG && format != ImageFormatPNG && format != ImageFormatGIF {
+		return false
+	}
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, allowedFormat := range allowed {
+		if strings.EqualFold(allowedFormat, format) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the largest dimensions within the bounds that keep the image's aspect ratio
+func fitImage(width int, height int, maxWidth int, maxHeight int) (int, int) {
+	scale := 1.0
+	if maxWidth > 0 && width > maxWidth {
+		scale = float64(maxWidth) / float64(width)
+	}
+	if maxHeight > 0 && height > maxHeight {
+		if heightScale := float64(maxHeight) / float64(height); heightScale < scale {
+			scale = heightScale
+		}
+	}
+	if scale == 1.0 {
+		return width, height
+	}
+
+	fitWidth, fitHeight := int(float64(width)*scale), int(float64(height)*scale)
+	if fitWidth < 1 {
+		fitWidth = 1
+	}
+	if fitHeight < 1 {
+		fitHeight = 1
+	}
+
+	return fitWidth, fitHeight
+}
+
+// Scales the image down using a box filter, averaging the source pixels
+// covered by every destination pixel
+func downscaleImage(src image.Image, width int, height int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / count),
+				G: uint16(g / count),
+				B: uint16(b / count),
+				A: uint16(a / count),
+			})
+		}
+	}
+
+	return dst
+}
+
+func encodeImage(img image.Image, format string, jpegQuality int) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	var err error
+	switch format {
+	case ImageFormatJPEG:
+		if jpegQuality == 0 {
+			jpegQuality = jpeg.DefaultQuality
+		}
+		err = jpeg.Encode(buf, img, &jpeg.Options{Quality: jpegQuality})
+	case ImageFormatPNG:
+		err = png.Encode(buf, img)
+	case ImageFormatGIF:
+		// only the first frame of animated GIFs is kept
+		err = gif.Encode(buf, img, nil)
+	default:
+		return nil, ImageValidationError{Kind: ImageErrorFormat, Format: format, Message: fmt.Sprintf("cannot encode images as %s", format)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
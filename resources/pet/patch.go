@@ -0,0 +1,109 @@
+package pet
+
+import (
+	fmt "fmt"
+	io "io"
+	types "pets_go/types"
+)
+
+// PatchResponse is the outcome of a partial pet update
+type PatchResponse struct {
+	// The pet as written to the server
+	Pet types.Pet
+	// JSON names of the fields whose value changed, empty if the update was skipped
+	ChangedFields []string
+}
+
+// PatchConflictError is returned when the pet kept changing concurrently and the
+// merge could not be written within the allowed number of retries
+type PatchConflictError struct {
+	PetId    int
+	Attempts int
+}
+
+func (e PatchConflictError) Error() string {
+	return fmt.Sprintf("pet %d changed concurrently on each of %d update attempts", e.PetId, e.Attempts)
+}
+
+// Partially update a pet.
+//
+// Fetches the current pet, merges the patch into it and writes the result with Update.
+// No update is sent if the patch leaves the pet unchanged. With CheckConflicts the pet is
+// read again right before writing and the merge is retried if it changed in between.
+//
+// GET /pet/{petId}, PUT /pet
+func (c *Client) Patch(request PatchRequest, reqModifiers ...RequestModifier) (PatchResponse, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return PatchResponse{}, err
+		}
+	}
+
+	maxRetries := request.MaxConflictRetries
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+
+	attempts := 0
+	for {
+		attempts++
+
+		current, err := c.getPet(request.PetId, reqModifiers)
+		if err != nil {
+			return PatchResponse{}, err
+		}
+		updated, err := request.Patch.Apply(current)
+		if err != nil {
+			return PatchResponse{}, err
+		}
+		changes, err := types.DiffPet(current, updated)
+		if err != nil {
+			return PatchResponse{}, err
+		}
+		if changes.IsEmpty() {
+			return PatchResponse{Pet: current, ChangedFields: []string{}}, nil
+		}
+
+		if request.CheckConflicts {
+			latest, err := c.getPet(request.PetId, reqModifiers)
+			if err != nil {
+				return PatchResponse{}, err
+			}
+			if !latest.Equal(current) {
+				if attempts > maxRetries {
+					return PatchResponse{}, PatchConflictError{PetId: request.PetId, Attempts: attempts}
+				}
+				continue
+			}
+		}
+
+		resp, err := c.Update(UpdateRequestFromPet(updated), reqModifiers...)
+		if err != nil {
+			return PatchResponse{}, err
+		}
+		resp.Body.Close()
+
+		return PatchResponse{Pet: updated, ChangedFields: changes.Fields()}, nil
+	}
+}
+
+// Fetches and decodes a pet
+func (c *Client) getPet(petId int, reqModifiers []RequestModifier) (types.Pet, error) {
+	resp, err := c.Get(GetRequest{PetId: petId}, reqModifiers...)
+	if err != nil {
+		return types.Pet{}, err
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return types.Pet{}, err
+	}
+	var bodyData types.Pet
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData)
+	if err != nil {
+		return types.Pet{}, err
+	}
+	return bodyData, nil
+}
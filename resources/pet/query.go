@@ -0,0 +1,279 @@
+package pet
+
+import (
+	heap "container/heap"
+	types "pets_go/types"
+	regexp "regexp"
+	sort "sort"
+	strings "strings"
+)
+
+// PetQuery composes the server-side status filter of FindByStatus with client-side
+// predicates, sorting & paging. Results are streamed with FindByStatusIter, so only the
+// pets being returned are held in memory: without sorting the query stops reading once
+// the limit is reached, with sorting and a limit only the best offset+limit pets are kept.
+// Sorting without a limit holds every matching pet.
+//
+//	pets, err := client.Pet.Query().
+//		Status(types.PetFindByStatusStatusEnumPending).
+//		CategoryName("Dogs").
+//		TaggedAll("vaccinated").
+//		NamePrefix("R").
+//		SortBy(pet.ByName).
+//		Limit(10).
+//		All()
+//
+// Builder methods modify and return the query, a query must not be modified while it runs
+type PetQuery struct {
+	client     *Client
+	statuses   []types.PetFindByStatusStatusEnum
+	predicates []func(types.Pet) bool
+	less       func(a types.Pet, b types.Pet) bool
+	limit      int
+	offset     int
+}
+
+// Starts a query over the pets of every status
+func (c *Client) Query() *PetQuery {
+	return &PetQuery{client: c}
+}
+
+// Restricts the query to pets having any of the statuses, filtered by the server
+func (q *PetQuery) Status(statuses ...types.PetFindByStatusStatusEnum) *PetQuery {
+	q.statuses = append(q.statuses, statuses...)
+	return q
+}
+
+// Keeps the pets the predicate reports true for
+func (q *PetQuery) Where(predicate func(types.Pet) bool) *PetQuery {
+	q.predicates = append(q.predicates, predicate)
+	return q
+}
+
+// Keeps the pets of the category with the ID
+func (q *PetQuery) CategoryID(id int) *PetQuery {
+	return q.Where(func(p types.Pet) bool {
+		categoryId, err := p.Category.OrZero().Id.Value()
+		return err == nil && categoryId == id
+	})
+}
+
+// Keeps the pets of the category with the name
+func (q *PetQuery) CategoryName(name string) *PetQuery {
+	return q.Where(func(p types.Pet) bool {
+		categoryName, err := p.Category.OrZero().Name.Value()
+		return err == nil && categoryName == name
+	})
+}
+
+// Keeps the pets tagged with at least one of the tag names
+func (q *PetQuery) TaggedAny(names ...string) *PetQuery {
+	return q.Where(func(p types.Pet) bool {
+		tagged := tagNames(p)
+		for _, name := range names {
+			if tagged[name] {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Keeps the pets tagged with every one of the tag names
+func (q *PetQuery) TaggedAll(names ...string) *PetQuery {
+	return q.Where(func(p types.Pet) bool {
+		tagged := tagNames(p)
+		for _, name := range names {
+			if !tagged[name] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Keeps the pets named exactly name
+func (q *PetQuery) NameEquals(name string) *PetQuery {
+	return q.Where(func(p types.Pet) bool {
+		return p.Name == name
+	})
+}
+
+// Keeps the pets whose name starts with the prefix
+func (q *PetQuery) NamePrefix(prefix string) *PetQuery {
+	return q.Where(func(p types.Pet) bool {
+		return strings.HasPrefix(p.Name, prefix)
+	})
+}
+
+// Keeps the pets whose name contains the substring, ignoring case
+func (q *PetQuery) NameContains(substring string) *PetQuery {
+	substring = strings.ToLower(substring)
+	return q.Where(func(p types.Pet) bool {
+		return strings.Contains(strings.ToLower(p.Name), substring)
+	})
+}
+
+// Keeps the pets whose name matches the regular expression
+func (q *PetQuery) NameMatches(pattern *regexp.Regexp) *PetQuery {
+	return q.Where(func(p types.Pet) bool {
+		return pattern.MatchString(p.Name)
+	})
+}
+
+// Keeps the pets having at least one photo URL, or none when has is false
+func (q *PetQuery) HasPhotos(has bool) *PetQuery {
+	return q.Where(func(p types.Pet) bool {
+		return len(p.PhotoUrls) > 0 == has
+	})
+}
+
+// Orders the results, see ByName, ByID & Descending
+func (q *PetQuery) SortBy(less func(a types.Pet, b types.Pet) bool) *PetQuery {
+	q.less = less
+	return q
+}
+
+// Returns at most n pets, all of them when n is 0
+func (q *PetQuery) Limit(n int) *PetQuery {
+	q.limit = n
+	return q
+}
+
+// Skips the first n matching pets
+func (q *PetQuery) Offset(n int) *PetQuery {
+	q.offset = n
+	return q
+}
+
+// Orders pets by name
+func ByName(a types.Pet, b types.Pet) bool {
+	return a.Name < b.Name
+}
+
+// Orders pets by ID, pets without an ID first
+func ByID(a types.Pet, b types.Pet) bool {
+	aId, aErr := a.Id.Value()
+	bId, bErr := b.Id.Value()
+	if aErr != nil || bErr != nil {
+		return aErr != nil && bErr == nil
+	}
+	return aId < bId
+}
+
+// Reverses an ordering
+func Descending(less func(a types.Pet, b types.Pet) bool) func(a types.Pet, b types.Pet) bool {
+	return func(a types.Pet, b types.Pet) bool {
+		return less(b, a)
+	}
+}
+
+// Runs the query, calling yield with each result in order. Iteration stops at the first
+// error, including one returned by yield, which is returned as is
+func (q *PetQuery) Each(yield func(types.Pet) error, reqModifiers ...RequestModifier) error {
+	statuses := q.statuses
+	if len(statuses) == 0 {
+		statuses = types.PetFindByStatusStatusEnumValues()
+	}
+	pets, err := q.client.FindByStatusIter(FindByStatusRequest{Statuses: statuses}, reqModifiers...)
+	if err != nil {
+		return err
+	}
+	defer pets.Close()
+
+	if q.less == nil {
+		skipped, returned := 0, 0
+		for (q.limit == 0 || returned < q.limit) && pets.Next() {
+			if !q.matches(pets.Pet()) {
+				continue
+			}
+			if skipped < q.offset {
+				skipped++
+				continue
+			}
+			if err := yield(pets.Pet()); err != nil {
+				return err
+			}
+			returned++
+		}
+		return pets.Err()
+	}
+
+	// keep the best offset+limit matches, the worst one on top of the heap
+	best := &petHeap{less: q.less}
+	for pets.Next() {
+		if !q.matches(pets.Pet()) {
+			continue
+		}
+		heap.Push(best, pets.Pet())
+		if q.limit > 0 && best.Len() > q.offset+q.limit {
+			heap.Pop(best)
+		}
+	}
+	if err := pets.Err(); err != nil {
+		return err
+	}
+
+	sorted := best.pets
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return q.less(sorted[i], sorted[j])
+	})
+	if q.offset >= len(sorted) {
+		return nil
+	}
+	for _, pet := range sorted[q.offset:] {
+		if err := yield(pet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Runs the query, returning every result
+func (q *PetQuery) All(reqModifiers ...RequestModifier) ([]types.Pet, error) {
+	results := []types.Pet{}
+	err := q.Each(func(p types.Pet) error {
+		results = append(results, p)
+		return nil
+	}, reqModifiers...)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (q *PetQuery) matches(p types.Pet) bool {
+	for _, predicate := range q.predicates {
+		if !predicate(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Names of the pet's tags
+func tagNames(p types.Pet) map[string]bool {
+	names := map[string]bool{}
+	for _, tag := range p.Tags.OrZero() {
+		if name, err := tag.Name.Value(); err == nil {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// Max-heap of pets under less, implementing heap.Interface
+type petHeap struct {
+	pets []types.Pet
+	less func(a types.Pet, b types.Pet) bool
+}
+
+func (h *petHeap) Len() int           { return len(h.pets) }
+func (h *petHeap) Less(i, j int) bool { return h.less(h.pets[j], h.pets[i]) }
+func (h *petHeap) Swap(i, j int)      { h.pets[i], h.pets[j] = h.pets[j], h.pets[i] }
+func (h *petHeap) Push(x interface{}) { h.pets = append(h.pets, x.(types.Pet)) }
+func (h *petHeap) Pop() interface{} {
+	last := h.pets[len(h.pets)-1]
+	h.pets = h.pets[:len(h.pets)-1]
+	return last
+}
@@ -1,7 +1,8 @@
 package pet
 
 import (
-	os "os"
+	json "encoding/json"
+	sdkcore "pets_go/core"
 	nullable "pets_go/nullable"
 	types "pets_go/types"
 )
@@ -16,6 +17,18 @@ type DeleteRequest struct {
 type FindByStatusRequest struct {
 	// Status values that need to be considered for filter
 	Status nullable.Nullable[types.PetFindByStatusStatusEnum] `json:"status,omitempty"`
+	// Further status values to filter by, pets having any of the statuses are returned
+	Statuses []types.PetFindByStatusStatusEnum `json:"-"`
+	// How multiple statuses are written to the query, exploded by default
+	StatusEncoding sdkcore.ArrayEncoding `json:"-"`
+}
+
+// FindByTagsRequest
+type FindByTagsRequest struct {
+	// Tags to filter by
+	Tags []string `json:"tags,omitempty"`
+	// How multiple tags are written to the query, exploded by default
+	TagsEncoding sdkcore.ArrayEncoding `json:"-"`
 }
 
 // GetRequest
@@ -33,15 +46,23 @@ type CreateRequest struct {
 	// pet status in the store
 	Status nullable.Nullable[types.PetStatusEnum] `json:"status,omitempty"`
 	Tags   nullable.Nullable[[]types.Tag]         `json:"tags,omitempty"`
+	// Properties unknown to the SDK, sent as is
+	AdditionalProperties map[string]json.RawMessage `json:"-"`
+	// Media type the body is sent as, any media type with a codec registered on the
+	// client, e.g. sdkcore.ContentTypeXML. ContentTypeJSON if empty
+	ContentType string `json:"-"`
 }
 
 // UploadImageRequest
 type UploadImageRequest struct {
-	Data os.File `json:"data"`
+	// Image content, see sdkcore.NewFileFromBytes, NewFileFromPath, NewFileFromFS & NewFile
+	Data sdkcore.File `json:"data"`
 	// ID of pet to update
 	PetId int `json:"petId"`
 	// Additional Metadata
 	AdditionalMetadata nullable.Nullable[string] `json:"additionalMetadata,omitempty"`
+	// Optional validation & normalization of the image before it is sent, see PrepareImage
+	ImageOptions *ImageOptions `json:"-"`
 }
 
 // UpdateRequest
@@ -53,4 +74,31 @@ type UpdateRequest struct {
 	// pet status in the store
 	Status nullable.Nullable[types.PetStatusEnum] `json:"status,omitempty"`
 	Tags   nullable.Nullable[[]types.Tag]         `json:"tags,omitempty"`
+	// Properties unknown to the SDK, sent as is
+	AdditionalProperties map[string]json.RawMessage `json:"-"`
+	// Media type the body is sent as, any media type with a codec registered on the
+	// client, e.g. sdkcore.ContentTypeXML. ContentTypeJSON if empty
+	ContentType string `json:"-"`
+}
+
+// PatchRequest
+type PatchRequest struct {
+	// ID of pet to update
+	PetId int `json:"petId"`
+	// Fields to change, undefined fields are left unchanged and null fields are removed
+	Patch types.PetPatch `json:"patch"`
+	// Re-read the pet right before writing and merge again if it changed in the meantime
+	CheckConflicts bool `json:"-"`
+	// Times a conflicting merge is retried when CheckConflicts is set, 3 if zero
+	MaxConflictRetries int `json:"-"`
+}
+
+// UpdateWithFormRequest
+type UpdateWithFormRequest struct {
+	// ID of pet that needs to be updated
+	PetId int `json:"petId"`
+	// Name of pet that needs to be updated
+	Name nullable.Nullable[string] `json:"name,omitempty"`
+	// Status of pet that needs to be updated
+	Status nullable.Nullable[types.PetStatusEnum] `json:"status,omitempty"`
 }
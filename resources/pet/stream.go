@@ -0,0 +1,186 @@
+package pet
+
+import (
+	context "context"
+	json "encoding/json"
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	mime "mime"
+	http "net/http"
+	sdkcore "pets_go/core"
+	types "pets_go/types"
+)
+
+// PetIterator decodes the pets of a JSON array response one at a time, holding a single
+// pet in memory regardless of the size of the response. It must be closed once done,
+// which Next does on its own when the array ends or an error occurs:
+//
+//	pets, err := client.Pet.FindByStatusIter(request)
+//	if err != nil { ... }
+//	defer pets.Close()
+//	for pets.Next() {
+//		pet := pets.Pet()
+//	}
+//	if err := pets.Err(); err != nil { ... }
+type PetIterator struct {
+	coreClient *sdkcore.CoreClient
+	body       io.ReadCloser
+	ctx        context.Context
+	decoder    *json.Decoder
+	started    bool
+	index      int
+	current    types.Pet
+	err        error
+	closed     bool
+}
+
+// Wraps a response, failing unless it holds JSON. The body is closed on failure
+func newPetIterator(coreClient *sdkcore.CoreClient, resp http.Response) (*PetIterator, error) {
+	ctx := context.Background()
+	if resp.Request != nil {
+		ctx = resp.Request.Context()
+	}
+	iter := &PetIterator{coreClient: coreClient, body: resp.Body, ctx: ctx, decoder: json.NewDecoder(resp.Body)}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || mediaType != sdkcore.ContentTypeJSON {
+			iter.Close()
+			return nil, fmt.Errorf("streaming requires a %s response, received %q", sdkcore.ContentTypeJSON, contentType)
+		}
+	}
+
+	return iter, nil
+}
+
+// Advances to the next pet, reporting false once the array ends or on error
+func (it *PetIterator) Next() bool {
+	if it.closed {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		return it.fail(err)
+	}
+
+	if !it.started {
+		it.started = true
+		token, err := it.decoder.Token()
+		if err == io.EOF || token == nil && err == nil {
+			// empty body or null
+			it.Close()
+			return false
+		}
+		if err != nil {
+			return it.fail(err)
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return it.fail(fmt.Errorf("expected a JSON array of pets, received %v", token))
+		}
+	}
+
+	if !it.decoder.More() {
+		// consume the closing bracket, failing on a truncated body
+		if _, err := it.decoder.Token(); err != nil {
+			return it.fail(err)
+		}
+		it.Close()
+		return false
+	}
+
+	// each pet is decoded by the client's JSON codec, applying its decoding policies
+	var raw json.RawMessage
+	if err := it.decoder.Decode(&raw); err != nil {
+		return it.fail(err)
+	}
+	var pet types.Pet
+	if err := it.coreClient.DecodeJSON(raw, &pet); err != nil {
+		return it.fail(prefixErrorPath(err, sdkcore.IndexPath("", it.index)))
+	}
+
+	it.current = pet
+	it.index++
+	return true
+}
+
+// Returns the pet Next advanced to
+func (it *PetIterator) Pet() types.Pet {
+	return it.current
+}
+
+// Returns the error that stopped the iteration, if any
+func (it *PetIterator) Err() error {
+	return it.err
+}
+
+// Closes the response body, safe to call more than once
+func (it *PetIterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	return it.body.Close()
+}
+
+func (it *PetIterator) fail(err error) bool {
+	if it.ctx.Err() != nil {
+		// the body was cut short by the cancellation
+		err = it.ctx.Err()
+	}
+	it.err = err
+	it.Close()
+	return false
+}
+
+// Places a decoding error of a single pet at its index in the array
+func prefixErrorPath(err error, prefix string) error {
+	var enumErr sdkcore.UnknownEnumError
+	if errors.As(err, &enumErr) {
+		enumErr.Path = sdkcore.JoinPath(prefix, enumErr.Path)
+		return enumErr
+	}
+	var fieldErr sdkcore.UnknownFieldError
+	if errors.As(err, &fieldErr) {
+		fieldErr.Path = sdkcore.JoinPath(prefix, fieldErr.Path)
+		return fieldErr
+	}
+	return fmt.Errorf("%s: %w", prefix, err)
+}
+
+// Finds Pets by status, decoding the results one at a time.
+//
+// Like FindByStatus, but the response is decoded incrementally so memory use stays
+// flat however many pets are returned. JSON is requested as only JSON is streamed.
+// Cancelling the request context, see sdkcore.WithContext, stops the iteration.
+//
+// GET /pet/findByStatus
+func (c *Client) FindByStatusIter(request FindByStatusRequest, reqModifiers ...RequestModifier) (*PetIterator, error) {
+	reqModifiers = append(reqModifiers[:len(reqModifiers):len(reqModifiers)], sdkcore.WithAccept(sdkcore.ContentTypeJSON))
+	resp, err := c.FindByStatus(request, reqModifiers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPetIterator(c.coreClient, resp)
+}
+
+// Finds Pets by status, calling yield with each pet as it is decoded.
+//
+// See FindByStatusIter. Iteration stops at the first error, including one returned by
+// yield, which is returned as is. The response body is always closed on return.
+//
+// GET /pet/findByStatus
+func (c *Client) FindByStatusEach(request FindByStatusRequest, yield func(types.Pet) error, reqModifiers ...RequestModifier) error {
+	pets, err := c.FindByStatusIter(request, reqModifiers...)
+	if err != nil {
+		return err
+	}
+	defer pets.Close()
+
+	for pets.Next() {
+		if err := yield(pets.Pet()); err != nil {
+			return err
+		}
+	}
+	return pets.Err()
+}
@@ -0,0 +1,83 @@
+package pet
+
+import (
+	sdkcore "pets_go/core"
+	types "pets_go/types"
+)
+
+// Checks the request against the constraints of the API schema
+func (r DeleteRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	v.NonNegative(r.PetId, "petId")
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r FindByStatusRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	if status, err := r.Status.Value(); err == nil {
+		if _, err := types.ParsePetFindByStatusStatusEnum(string(status)); err != nil {
+			v.Add("status", err.Error())
+		}
+	}
+	for i, status := range r.Statuses {
+		if _, err := types.ParsePetFindByStatusStatusEnum(string(status)); err != nil {
+			v.Add(sdkcore.IndexPath("statuses", i), err.Error())
+		}
+	}
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r GetRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	v.NonNegative(r.PetId, "petId")
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r CreateRequest) Validate() error {
+	return r.ToPet().Validate()
+}
+
+// Checks the request against the constraints of the API schema
+func (r UploadImageRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	v.Check(!r.Data.IsEmpty(), "data", "is required")
+	v.NonNegative(r.PetId, "petId")
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r UpdateRequest) Validate() error {
+	return r.ToPet().Validate()
+}
+
+// Checks the request against the constraints of the API schema
+func (r PatchRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	v.NonNegative(r.PetId, "petId")
+	r.Patch.ValidateAt(v, "patch")
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r FindByTagsRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	for i, tag := range r.Tags {
+		v.Check(tag != "", sdkcore.IndexPath("tags", i), "must not be empty")
+	}
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r UpdateWithFormRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	v.NonNegative(r.PetId, "petId")
+	if status, err := r.Status.Value(); err == nil {
+		if _, err := types.ParsePetStatusEnum(string(status)); err != nil {
+			v.Add("status", err.Error())
+		}
+	}
+	return v.Err()
+}
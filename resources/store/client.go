@@ -1,13 +1,17 @@
 package store
 
 import (
+	io "io"
 	http "net/http"
 	sdkcore "pets_go/core"
+	pet "pets_go/resources/pet"
 	order "pets_go/resources/store/order"
+	types "pets_go/types"
 )
 
 type Client struct {
 	coreClient *sdkcore.CoreClient
+	pet        *pet.Client
 	Order      *order.Client
 }
 type RequestModifier = func(req *http.Request) error
@@ -16,8 +20,68 @@ type RequestModifier = func(req *http.Request) error
 func NewClient(coreClient *sdkcore.CoreClient) *Client {
 	client := Client{
 		coreClient: coreClient,
+		pet:        pet.NewClient(coreClient),
 		Order:      order.NewClient(coreClient),
 	}
 
 	return &client
 }
+
+// Returns pet inventories by status.
+//
+// Returns a map of status codes to quantities.
+//
+// GET /store/inventory
+func (c *Client) Inventory(reqModifiers ...RequestModifier) (types.Inventory, error) {
+	// URL formatting
+	targetUrl, err := c.coreClient.BuildURL("/store/" + "inventory")
+	if err != nil {
+		return types.Inventory{}, err
+	}
+
+	// Init request
+	req, err := http.NewRequest("GET", targetUrl.String(), nil)
+	if err != nil {
+		return types.Inventory{}, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON))
+
+	// Add auth
+	err = c.coreClient.AddAuth(req, "api_key")
+	if err != nil {
+		return types.Inventory{}, err
+	}
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return types.Inventory{}, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return types.Inventory{}, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return types.Inventory{}, sdkcore.NewApiError(*req, *resp)
+	}
+
+	// Handle response
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return types.Inventory{}, err
+	}
+	var bodyData types.Inventory
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData)
+	if err != nil {
+		return types.Inventory{}, err
+	}
+	return bodyData, nil
+
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	errors "errors"
+	http "net/http"
+	sdkcore "pets_go/core"
+	pet "pets_go/resources/pet"
+	types "pets_go/types"
+)
+
+// Computes the inventory client-side, counting the pets of every status returned by
+// FindByStatus. Pets are streamed, so memory use stays flat however many there are.
+//
+// Only the statuses of types.PetFindByStatusStatusEnum can be queried, pets without a
+// status are not counted
+//
+// GET /pet/findByStatus
+func (c *Client) InventoryFromPets(reqModifiers ...RequestModifier) (types.Inventory, error) {
+	inventory := types.Inventory{}
+	err := c.pet.FindByStatusEach(
+		pet.FindByStatusRequest{Statuses: types.PetFindByStatusStatusEnumValues()},
+		func(p types.Pet) error {
+			if status, err := p.Status.Value(); err == nil {
+				inventory[string(status)]++
+			}
+			return nil
+		},
+		reqModifiers...,
+	)
+	if err != nil {
+		return types.Inventory{}, err
+	}
+	return inventory.Reconcile(), nil
+}
+
+// Returns pet inventories by status, computing them with InventoryFromPets when the
+// server doesn't implement GET /store/inventory, i.e. answers 404, 405 or 501. Either
+// way the inventory is reconciled, listing every types.PetStatusEnum value
+//
+// GET /store/inventory
+func (c *Client) InventoryOrFallback(reqModifiers ...RequestModifier) (types.Inventory, error) {
+	inventory, err := c.Inventory(reqModifiers...)
+	if isNotImplemented(err) {
+		return c.InventoryFromPets(reqModifiers...)
+	}
+	if err != nil {
+		return types.Inventory{}, err
+	}
+	return inventory.Reconcile(), nil
+}
+
+func isNotImplemented(err error) bool {
+	var apiErr sdkcore.ApiError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
@@ -1,12 +1,10 @@
 package order
 
 import (
-	json "encoding/json"
 	io "io"
 	http "net/http"
 	sdkcore "pets_go/core"
 	types "pets_go/types"
-	strings "strings"
 )
 
 type Client struct {
@@ -29,6 +27,13 @@ func NewClient(coreClient *sdkcore.CoreClient) *Client {
 //
 // DELETE /store/order/{orderId}
 func (c *Client) Delete(request DeleteRequest, reqModifiers ...RequestModifier) (http.Response, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return http.Response{}, err
+		}
+	}
+
 	// URL formatting
 	targetUrl, err := c.coreClient.BuildURL("/store/" + "order/" + sdkcore.FmtStringParam(request.OrderId))
 	if err != nil {
@@ -76,6 +81,13 @@ func (c *Client) Delete(request DeleteRequest, reqModifiers ...RequestModifier)
 //
 // GET /store/order/{orderId}
 func (c *Client) Get(request GetRequest, reqModifiers ...RequestModifier) (http.Response, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return http.Response{}, err
+		}
+	}
+
 	// URL formatting
 	targetUrl, err := c.coreClient.BuildURL("/store/" + "order/" + sdkcore.FmtStringParam(request.OrderId))
 	if err != nil {
@@ -90,6 +102,7 @@ func (c *Client) Get(request GetRequest, reqModifiers ...RequestModifier) (http.
 
 	// Add headers
 	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
 
 	// Add auth
 	err = c.coreClient.AddAuth(req, "api_key")
@@ -123,6 +136,13 @@ func (c *Client) Get(request GetRequest, reqModifiers ...RequestModifier) (http.
 //
 // POST /store/order
 func (c *Client) Create(request CreateRequest, reqModifiers ...RequestModifier) (types.Order, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return types.Order{}, err
+		}
+	}
+
 	// URL formatting
 	targetUrl, err := c.coreClient.BuildURL("/store/" + "order")
 	if err != nil {
@@ -130,31 +150,30 @@ func (c *Client) Create(request CreateRequest, reqModifiers ...RequestModifier)
 	}
 
 	// Prep body
-	reqBodyBuf := &strings.Reader{}
-	reqBodyBuf, err = sdkcore.FormUrlEncodedBody(
-		types.Order{
-			Complete: request.Complete,
-			Id:       request.Id,
-			PetId:    request.PetId,
-			Quantity: request.Quantity,
-			ShipDate: request.ShipDate,
-			Status:   request.Status,
-		},
-		map[string]string{
-			"complete": "form",
-			"id":       "form",
-			"petId":    "form",
-			"quantity": "form",
-			"shipDate": "form",
-			"status":   "form",
-		},
-		map[string]bool{
-			"complete": true,
-			"id":       true,
-			"petId":    true,
-			"quantity": true,
-			"shipDate": true,
-			"status":   true,
+	contentType := request.ContentType
+	if contentType == "" {
+		contentType = sdkcore.ContentTypeFormUrlEncoded
+	}
+	reqBodyBuf, contentType, err := c.coreClient.EncodeBody(
+		request.ToOrder(),
+		contentType,
+		sdkcore.EncodeOptions{
+			FormStyle: map[string]string{
+				"complete": "form",
+				"id":       "form",
+				"petId":    "form",
+				"quantity": "form",
+				"shipDate": "form",
+				"status":   "form",
+			},
+			FormExplode: map[string]bool{
+				"complete": true,
+				"id":       true,
+				"petId":    true,
+				"quantity": true,
+				"shipDate": true,
+				"status":   true,
+			},
 		},
 	)
 	if err != nil {
@@ -169,7 +188,8 @@ func (c *Client) Create(request CreateRequest, reqModifiers ...RequestModifier)
 
 	// Add headers
 	req.Header.Add("x-sideko-sdk-language", "Go")
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
+	req.Header.Add("Content-Type", contentType)
 
 	// Add auth
 	err = c.coreClient.AddAuth(req, "api_key")
@@ -200,7 +220,7 @@ func (c *Client) Create(request CreateRequest, reqModifiers ...RequestModifier)
 		return types.Order{}, err
 	}
 	var bodyData types.Order
-	err = json.Unmarshal(body, &bodyData)
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData)
 	if err != nil {
 		return types.Order{}, err
 	}
@@ -0,0 +1,33 @@
+package order
+
+import (
+	types "pets_go/types"
+)
+
+// Builds a CreateRequest holding every field of the order
+func CreateRequestFromOrder(order types.Order) CreateRequest {
+	return CreateRequest{
+		Complete: order.Complete,
+		Id:       order.Id,
+		PetId:    order.PetId,
+		Quantity: order.Quantity,
+		ShipDate: order.ShipDate,
+		Status:   order.Status,
+
+		AdditionalProperties: order.AdditionalProperties,
+	}
+}
+
+// Returns the order described by the request
+func (r CreateRequest) ToOrder() types.Order {
+	return types.Order{
+		Complete: r.Complete,
+		Id:       r.Id,
+		PetId:    r.PetId,
+		Quantity: r.Quantity,
+		ShipDate: r.ShipDate,
+		Status:   r.Status,
+
+		AdditionalProperties: r.AdditionalProperties,
+	}
+}
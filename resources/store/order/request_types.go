@@ -1,6 +1,7 @@
 package order
 
 import (
+	json "encoding/json"
 	nullable "pets_go/nullable"
 	types "pets_go/types"
 )
@@ -19,11 +20,16 @@ type GetRequest struct {
 
 // CreateRequest
 type CreateRequest struct {
-	Complete nullable.Nullable[bool]   `json:"complete,omitempty"`
-	Id       nullable.Nullable[int]    `json:"id,omitempty"`
-	PetId    nullable.Nullable[int]    `json:"petId,omitempty"`
-	Quantity nullable.Nullable[int]    `json:"quantity,omitempty"`
-	ShipDate nullable.Nullable[string] `json:"shipDate,omitempty"`
+	Complete nullable.Nullable[bool]           `json:"complete,omitempty"`
+	Id       nullable.Nullable[int]            `json:"id,omitempty"`
+	PetId    nullable.Nullable[int]            `json:"petId,omitempty"`
+	Quantity nullable.Nullable[int]            `json:"quantity,omitempty"`
+	ShipDate nullable.Nullable[types.DateTime] `json:"shipDate,omitempty"`
 	// Order Status
 	Status nullable.Nullable[types.OrderStatusEnum] `json:"status,omitempty"`
+	// Properties unknown to the SDK, sent as is
+	AdditionalProperties map[string]json.RawMessage `json:"-"`
+	// Media type the body is sent as, any media type with a codec registered on the
+	// client, e.g. sdkcore.ContentTypeXML. ContentTypeFormUrlEncoded if empty
+	ContentType string `json:"-"`
 }
@@ -0,0 +1,24 @@
+package order
+
+import (
+	sdkcore "pets_go/core"
+)
+
+// Checks the request against the constraints of the API schema
+func (r DeleteRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	v.NonNegative(r.OrderId, "orderId")
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r GetRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	v.NonNegative(r.OrderId, "orderId")
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r CreateRequest) Validate() error {
+	return r.ToOrder().Validate()
+}
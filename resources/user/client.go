@@ -0,0 +1,523 @@
+package user
+
+import (
+	io "io"
+	mime "mime"
+	http "net/http"
+	url "net/url"
+	sdkcore "pets_go/core"
+	types "pets_go/types"
+)
+
+type Client struct {
+	coreClient *sdkcore.CoreClient
+}
+type RequestModifier = func(req *http.Request) error
+
+// Instantiate a new resource client
+func NewClient(coreClient *sdkcore.CoreClient) *Client {
+	client := Client{
+		coreClient: coreClient,
+	}
+
+	return &client
+}
+
+// Delete user resource.
+//
+// This can only be done by the logged in user.
+//
+// DELETE /user/{username}
+func (c *Client) Delete(request DeleteRequest, reqModifiers ...RequestModifier) (http.Response, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return http.Response{}, err
+		}
+	}
+
+	// URL formatting
+	targetUrl, err := c.coreClient.BuildURL("/user/" + url.PathEscape(sdkcore.FmtStringParam(request.Username)))
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Init request
+	req, err := http.NewRequest("DELETE", targetUrl.String(), nil)
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+
+	// Add auth
+	err = c.coreClient.AddAuth(req, "api_key")
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return http.Response{}, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return http.Response{}, sdkcore.NewApiError(*req, *resp)
+	}
+
+	return *resp, nil
+
+}
+
+// Get user by user name.
+//
+// Get user detail based on username.
+//
+// GET /user/{username}
+func (c *Client) Get(request GetRequest, reqModifiers ...RequestModifier) (types.User, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return types.User{}, err
+		}
+	}
+
+	// URL formatting
+	targetUrl, err := c.coreClient.BuildURL("/user/" + url.PathEscape(sdkcore.FmtStringParam(request.Username)))
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Init request
+	req, err := http.NewRequest("GET", targetUrl.String(), nil)
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
+
+	// Add auth
+	err = c.coreClient.AddAuth(req, "api_key")
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return types.User{}, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return types.User{}, sdkcore.NewApiError(*req, *resp)
+	}
+
+	// Handle response
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return types.User{}, err
+	}
+	var bodyData types.User
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData)
+	if err != nil {
+		return types.User{}, err
+	}
+	return bodyData, nil
+
+}
+
+// Create user.
+//
+// This can only be done by the logged in user.
+//
+// POST /user
+func (c *Client) Create(request CreateRequest, reqModifiers ...RequestModifier) (types.User, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return types.User{}, err
+		}
+	}
+
+	// URL formatting
+	targetUrl, err := c.coreClient.BuildURL("/user")
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Prep body
+	contentType := request.ContentType
+	if contentType == "" {
+		contentType = sdkcore.ContentTypeJSON
+	}
+	reqBodyBuf, contentType, err := c.coreClient.EncodeBody(
+		request.ToUser(),
+		contentType,
+		sdkcore.EncodeOptions{
+			FormStyle: map[string]string{
+				"email":      "form",
+				"firstName":  "form",
+				"id":         "form",
+				"lastName":   "form",
+				"password":   "form",
+				"phone":      "form",
+				"userStatus": "form",
+				"username":   "form",
+			},
+			FormExplode: map[string]bool{
+				"email":      true,
+				"firstName":  true,
+				"id":         true,
+				"lastName":   true,
+				"password":   true,
+				"phone":      true,
+				"userStatus": true,
+				"username":   true,
+			},
+		},
+	)
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Init request
+	req, err := http.NewRequest("POST", targetUrl.String(), reqBodyBuf)
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
+	req.Header.Add("Content-Type", contentType)
+
+	// Add auth
+	err = c.coreClient.AddAuth(req, "api_key")
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return types.User{}, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return types.User{}, sdkcore.NewApiError(*req, *resp)
+	}
+
+	// Handle response
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return types.User{}, err
+	}
+	var bodyData types.User
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData)
+	if err != nil {
+		return types.User{}, err
+	}
+	return bodyData, nil
+
+}
+
+// Creates list of users with given input array.
+//
+// Creates list of users with given input array.
+//
+// POST /user/createWithList
+func (c *Client) CreateWithList(request CreateWithListRequest, reqModifiers ...RequestModifier) (types.User, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return types.User{}, err
+		}
+	}
+
+	// URL formatting
+	targetUrl, err := c.coreClient.BuildURL("/user/" + "createWithList")
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Prep body
+	contentType := request.ContentType
+	if contentType == "" {
+		contentType = sdkcore.ContentTypeJSON
+	}
+	reqBodyBuf, contentType, err := c.coreClient.EncodeBody(
+		request.Data,
+		contentType,
+		sdkcore.EncodeOptions{},
+	)
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Init request
+	req, err := http.NewRequest("POST", targetUrl.String(), reqBodyBuf)
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
+	req.Header.Add("Content-Type", contentType)
+
+	// Add auth
+	err = c.coreClient.AddAuth(req, "api_key")
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return types.User{}, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return types.User{}, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return types.User{}, sdkcore.NewApiError(*req, *resp)
+	}
+
+	// Handle response
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return types.User{}, err
+	}
+	var bodyData types.User
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData)
+	if err != nil {
+		return types.User{}, err
+	}
+	return bodyData, nil
+
+}
+
+// Update user resource.
+//
+// This can only be done by the logged in user.
+//
+// PUT /user/{username}
+func (c *Client) Update(request UpdateRequest, reqModifiers ...RequestModifier) (http.Response, error) {
+	// Validate request
+	if c.coreClient.ValidateRequests {
+		if err := request.Validate(); err != nil {
+			return http.Response{}, err
+		}
+	}
+
+	// URL formatting
+	targetUrl, err := c.coreClient.BuildURL("/user/" + url.PathEscape(sdkcore.FmtStringParam(request.CurrentUsername)))
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Prep body
+	contentType := request.ContentType
+	if contentType == "" {
+		contentType = sdkcore.ContentTypeJSON
+	}
+	reqBodyBuf, contentType, err := c.coreClient.EncodeBody(
+		request.ToUser(),
+		contentType,
+		sdkcore.EncodeOptions{
+			FormStyle: map[string]string{
+				"email":      "form",
+				"firstName":  "form",
+				"id":         "form",
+				"lastName":   "form",
+				"password":   "form",
+				"phone":      "form",
+				"userStatus": "form",
+				"username":   "form",
+			},
+			FormExplode: map[string]bool{
+				"email":      true,
+				"firstName":  true,
+				"id":         true,
+				"lastName":   true,
+				"password":   true,
+				"phone":      true,
+				"userStatus": true,
+				"username":   true,
+			},
+		},
+	)
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Init request
+	req, err := http.NewRequest("PUT", targetUrl.String(), reqBodyBuf)
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Content-Type", contentType)
+
+	// Add auth
+	err = c.coreClient.AddAuth(req, "api_key")
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return http.Response{}, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return http.Response{}, sdkcore.NewApiError(*req, *resp)
+	}
+
+	return *resp, nil
+
+}
+
+// Logs user into the system.
+//
+// Returns the session token along with the rate limit & expiry the server reports in the
+// X-Rate-Limit & X-Expires-After headers, see NewSession to keep a session alive
+//
+// GET /user/login
+func (c *Client) Login(request LoginRequest, reqModifiers ...RequestModifier) (LoginResponse, error) {
+	// URL formatting
+	targetUrl, err := c.coreClient.BuildURL("/user/" + "login")
+	if err != nil {
+		return LoginResponse{}, err
+	}
+
+	// Query params
+	params := targetUrl.Query()
+	sdkcore.AddQueryParam(params, "username", request.Username, "form", true)
+	sdkcore.AddQueryParam(params, "password", request.Password, "form", true)
+	targetUrl.RawQuery = sdkcore.CanonicalQueryString(params)
+
+	// Init request
+	req, err := http.NewRequest("GET", targetUrl.String(), nil)
+	if err != nil {
+		return LoginResponse{}, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+	req.Header.Add("Accept", c.coreClient.Accept(sdkcore.ContentTypeJSON, sdkcore.ContentTypeXML))
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return LoginResponse{}, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return LoginResponse{}, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return LoginResponse{}, sdkcore.NewApiError(*req, *resp)
+	}
+
+	// Handle response
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return LoginResponse{}, err
+	}
+	bodyData := newLoginResponse(resp.Header)
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "text/plain" {
+		// some servers send the bare token
+		bodyData.Token = string(body)
+		return bodyData, nil
+	}
+	err = c.coreClient.DecodeBody(resp.Header.Get("Content-Type"), body, &bodyData.Token)
+	if err != nil {
+		return LoginResponse{}, err
+	}
+	return bodyData, nil
+
+}
+
+// Logs out current logged in user session.
+//
+// Log user out of the system.
+//
+// GET /user/logout
+func (c *Client) Logout(reqModifiers ...RequestModifier) (http.Response, error) {
+	// URL formatting
+	targetUrl, err := c.coreClient.BuildURL("/user/" + "logout")
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Init request
+	req, err := http.NewRequest("GET", targetUrl.String(), nil)
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Add headers
+	req.Header.Add("x-sideko-sdk-language", "Go")
+
+	// Add base client & request level modifiers
+	if err := c.coreClient.ApplyModifiers(req, reqModifiers); err != nil {
+		return http.Response{}, err
+	}
+
+	// Dispatch request
+	resp, err := c.coreClient.HttpClient.Do(req)
+	if err != nil {
+		return http.Response{}, err
+	}
+
+	// Check status
+	if resp.StatusCode >= 300 {
+		return http.Response{}, sdkcore.NewApiError(*req, *resp)
+	}
+
+	return *resp, nil
+
+}
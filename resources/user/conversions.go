@@ -0,0 +1,70 @@
+package user
+
+import (
+	types "pets_go/types"
+)
+
+// Builds a CreateRequest holding every field of the user
+func CreateRequestFromUser(user types.User) CreateRequest {
+	return CreateRequest{
+		Email:      user.Email,
+		FirstName:  user.FirstName,
+		Id:         user.Id,
+		LastName:   user.LastName,
+		Password:   user.Password,
+		Phone:      user.Phone,
+		Username:   user.Username,
+		UserStatus: user.UserStatus,
+
+		AdditionalProperties: user.AdditionalProperties,
+	}
+}
+
+// Returns the user described by the request
+func (r CreateRequest) ToUser() types.User {
+	return types.User{
+		Email:      r.Email,
+		FirstName:  r.FirstName,
+		Id:         r.Id,
+		LastName:   r.LastName,
+		Password:   r.Password,
+		Phone:      r.Phone,
+		Username:   r.Username,
+		UserStatus: r.UserStatus,
+
+		AdditionalProperties: r.AdditionalProperties,
+	}
+}
+
+// Builds an UpdateRequest replacing the user named username with every field of the user
+func UpdateRequestFromUser(username string, user types.User) UpdateRequest {
+	return UpdateRequest{
+		CurrentUsername: username,
+		Email:           user.Email,
+		FirstName:       user.FirstName,
+		Id:              user.Id,
+		LastName:        user.LastName,
+		Password:        user.Password,
+		Phone:           user.Phone,
+		Username:        user.Username,
+		UserStatus:      user.UserStatus,
+
+		AdditionalProperties: user.AdditionalProperties,
+	}
+}
+
+// Returns the user described by the request
+func (r UpdateRequest) ToUser() types.User {
+	return types.User{
+		Email:      r.Email,
+		FirstName:  r.FirstName,
+		Id:         r.Id,
+		LastName:   r.LastName,
+		Password:   r.Password,
+		Phone:      r.Phone,
+		Username:   r.Username,
+		UserStatus: r.UserStatus,
+
+		AdditionalProperties: r.AdditionalProperties,
+	}
+}
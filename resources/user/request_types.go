@@ -0,0 +1,74 @@
+package user
+
+import (
+	json "encoding/json"
+	nullable "pets_go/nullable"
+	types "pets_go/types"
+)
+
+// DeleteRequest
+type DeleteRequest struct {
+	// The name that needs to be deleted
+	Username string `json:"username"`
+}
+
+// GetRequest
+type GetRequest struct {
+	// The name that needs to be fetched. Use user1 for testing
+	Username string `json:"username"`
+}
+
+// CreateRequest
+type CreateRequest struct {
+	Email     nullable.Nullable[string] `json:"email,omitempty"`
+	FirstName nullable.Nullable[string] `json:"firstName,omitempty"`
+	Id        nullable.Nullable[int]    `json:"id,omitempty"`
+	LastName  nullable.Nullable[string] `json:"lastName,omitempty"`
+	Password  nullable.Nullable[string] `json:"password,omitempty"`
+	Phone     nullable.Nullable[string] `json:"phone,omitempty"`
+	Username  nullable.Nullable[string] `json:"username,omitempty"`
+	// User Status
+	UserStatus nullable.Nullable[int] `json:"userStatus,omitempty"`
+	// Properties unknown to the SDK, sent as is
+	AdditionalProperties map[string]json.RawMessage `json:"-"`
+	// Media type the body is sent as, any media type with a codec registered on the
+	// client, e.g. sdkcore.ContentTypeXML. ContentTypeJSON if empty
+	ContentType string `json:"-"`
+}
+
+// CreateWithListRequest
+type CreateWithListRequest struct {
+	// Users to create
+	Data []types.User `json:"data"`
+	// Media type the body is sent as, any media type with a codec registered on the
+	// client, e.g. sdkcore.ContentTypeXML. ContentTypeJSON if empty
+	ContentType string `json:"-"`
+}
+
+// UpdateRequest
+type UpdateRequest struct {
+	// Name that needs to be updated, the user's Username may differ to rename the user
+	CurrentUsername string                    `json:"-"`
+	Email           nullable.Nullable[string] `json:"email,omitempty"`
+	FirstName       nullable.Nullable[string] `json:"firstName,omitempty"`
+	Id              nullable.Nullable[int]    `json:"id,omitempty"`
+	LastName        nullable.Nullable[string] `json:"lastName,omitempty"`
+	Password        nullable.Nullable[string] `json:"password,omitempty"`
+	Phone           nullable.Nullable[string] `json:"phone,omitempty"`
+	Username        nullable.Nullable[string] `json:"username,omitempty"`
+	// User Status
+	UserStatus nullable.Nullable[int] `json:"userStatus,omitempty"`
+	// Properties unknown to the SDK, sent as is
+	AdditionalProperties map[string]json.RawMessage `json:"-"`
+	// Media type the body is sent as, any media type with a codec registered on the
+	// client, e.g. sdkcore.ContentTypeXML. ContentTypeJSON if empty
+	ContentType string `json:"-"`
+}
+
+// LoginRequest
+type LoginRequest struct {
+	// The user name for login
+	Username nullable.Nullable[string] `json:"username,omitempty"`
+	// The password for login in clear text
+	Password nullable.Nullable[string] `json:"password,omitempty"`
+}
@@ -0,0 +1,263 @@
+package user
+
+import (
+	http "net/http"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	types "pets_go/types"
+	strconv "strconv"
+	strings "strings"
+	sync "sync"
+	time "time"
+)
+
+// LoginResponse
+type LoginResponse struct {
+	// Session token
+	Token string
+	// Calls per hour allowed by the user, from the X-Rate-Limit header
+	RateLimit nullable.Nullable[int]
+	// Date in UTC when the token expires, from the X-Expires-After header
+	ExpiresAfter nullable.Nullable[types.DateTime]
+	// server's clock when it answered, from the Date header, zero if missing
+	date time.Time
+}
+
+// Reads the rate limit & expiry headers, headers that fail to parse are left undefined
+func newLoginResponse(header http.Header) LoginResponse {
+	res := LoginResponse{}
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		res.date = date
+	}
+	if rateLimit, err := strconv.Atoi(strings.TrimSpace(header.Get("X-Rate-Limit"))); err == nil {
+		res.RateLimit = nullable.NewValue(rateLimit)
+	}
+
+	expiresAfter := strings.TrimSpace(header.Get("X-Expires-After"))
+	if expiresAfter == "" {
+		return res
+	}
+	if expires, err := types.ParseDateTime(expiresAfter); err == nil {
+		res.ExpiresAfter = nullable.NewValue(expires)
+	} else if expires, err := http.ParseTime(expiresAfter); err == nil {
+		res.ExpiresAfter = nullable.NewValue(types.NewDateTime(expires))
+	} else if expires, err := time.Parse(time.UnixDate, expiresAfter); err == nil {
+		// Java's Date.toString, sent by the reference petstore server
+		res.ExpiresAfter = nullable.NewValue(types.NewDateTime(expires))
+	}
+	return res
+}
+
+// SessionOptions
+type SessionOptions struct {
+	// Auth scheme the session is registered as on the client, "api_key" if empty, the
+	// scheme every operation applies
+	AuthName string
+	// Applies the token to requests, the api_key header if nil
+	RequestMutator sdkcore.AuthProvider
+	// Time before expiry the session logs in again, 1 minute if zero. It is capped at half
+	// the token's lifetime so short lived tokens are not renewed on every request
+	RefreshBefore time.Duration
+}
+
+// Session keeps a user logged in, applying its token to the client's requests as an
+// sdkcore.AuthProvider registered on the client. It logs in again before the token expires
+// according to X-Expires-After, a token without an expiry is kept until Refresh is called.
+// The token's lifetime is measured from the response's Date header when there is one, so
+// a skewed local clock doesn't shorten it, and a token already expiring when it is handed
+// out is kept as if it had no expiry rather than logging in again on every request.
+//
+// A Session is safe for concurrent use, a single login runs at a time while requests
+// wait for its token. It must be created before the client is shared between goroutines
+// as it registers itself on the client
+type Session struct {
+	client        *Client
+	request       LoginRequest
+	reqModifiers  []RequestModifier
+	mutator       sdkcore.AuthProvider
+	refreshBefore time.Duration
+	// provider the session replaced, applied once it is closed
+	previous sdkcore.AuthProvider
+
+	mu       sync.Mutex
+	login    LoginResponse
+	loggedIn time.Time
+	// when the token expires on the local clock, zero if unknown
+	expires time.Time
+	// start of the hour the calls are counted in
+	windowStart time.Time
+	calls       int
+	closed      bool
+}
+
+// Logs the user in and registers the session on the client, so every following request is
+// sent with its token. The request modifiers are applied to the session's login & logout
+//
+// GET /user/login
+func (c *Client) NewSession(request LoginRequest, options SessionOptions, reqModifiers ...RequestModifier) (*Session, error) {
+	authName := options.AuthName
+	if authName == "" {
+		authName = "api_key"
+	}
+	mutator := options.RequestMutator
+	if mutator == nil {
+		mutator = sdkcore.NewAuthKeyHeader("api_key", "")
+	}
+	refreshBefore := options.RefreshBefore
+	if refreshBefore == 0 {
+		refreshBefore = time.Minute
+	}
+
+	session := &Session{
+		client:        c,
+		request:       request,
+		reqModifiers:  reqModifiers,
+		mutator:       mutator,
+		refreshBefore: refreshBefore,
+		previous:      c.coreClient.Auth[authName],
+	}
+	if err := session.Refresh(); err != nil {
+		return nil, err
+	}
+
+	c.coreClient.Auth[authName] = session
+	return session, nil
+}
+
+// Logs in again, replacing the token
+func (s *Session) Refresh() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.refreshLocked()
+}
+
+func (s *Session) refreshLocked() error {
+	login, err := s.client.Login(s.request, s.reqModifiers...)
+	if err != nil {
+		return err
+	}
+	s.login, s.loggedIn, s.expires = login, time.Now(), time.Time{}
+	s.windowStart, s.calls = s.loggedIn, 0
+
+	if expires, err := login.ExpiresAfter.Value(); err == nil {
+		lifetime := expires.Sub(s.loggedIn)
+		if !login.date.IsZero() {
+			lifetime = expires.Sub(login.date)
+		}
+		s.expires = s.loggedIn.Add(lifetime)
+		if s.expiringLocked() {
+			// logging in again would only hand out another such token
+			s.expires = time.Time{}
+		}
+	}
+	return nil
+}
+
+// Applies the token to the request, logging in first if it is about to expire. Once the
+// session is closed the provider it replaced is applied instead, if any
+func (s *Session) Apply(req *http.Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		if s.previous != nil {
+			return s.previous.Apply(req)
+		}
+		return nil
+	}
+	if s.expiringLocked() {
+		if err := s.refreshLocked(); err != nil {
+			return err
+		}
+	}
+	if time.Since(s.windowStart) >= time.Hour {
+		// the rate limit is per hour
+		s.windowStart, s.calls = time.Now(), 0
+	}
+	s.calls++
+	return s.applyTokenLocked(req)
+}
+
+// Replaces the token, e.g. one restored from a previous session, its expiry is unknown
+func (s *Session) SetValue(val *string) {
+	if val == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.login = LoginResponse{Token: *val, RateLimit: s.login.RateLimit}
+	s.expires = time.Time{}
+}
+
+// Returns the current token
+func (s *Session) Token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.login.Token
+}
+
+// Returns when the current token expires on the local clock, false if the server didn't
+// tell or the token was already expiring when it was handed out
+func (s *Session) ExpiresAt() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.expires, !s.expires.IsZero()
+}
+
+// Returns the calls per hour allowed by the server, false if it didn't tell
+func (s *Session) RateLimit() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rateLimit, err := s.login.RateLimit.Value()
+	return rateLimit, err == nil
+}
+
+// Returns the calls left this hour, counting the requests the session was applied to
+// since the last login or the start of the hour, false if the server reported no rate limit
+func (s *Session) RemainingCalls() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rateLimit, err := s.login.RateLimit.Value()
+	if err != nil {
+		return 0, false
+	}
+	if s.calls >= rateLimit {
+		return 0, true
+	}
+	return rateLimit - s.calls, true
+}
+
+// Logs the user out, afterwards the client's requests are sent with the provider the
+// session replaced. Safe to call more than once, only the first call logs out
+//
+// GET /user/logout
+func (s *Session) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	reqModifiers := append(s.reqModifiers[:len(s.reqModifiers):len(s.reqModifiers)], s.applyTokenLocked)
+	_, err := s.client.Logout(reqModifiers...)
+	return err
+}
+
+func (s *Session) applyTokenLocked(req *http.Request) error {
+	token := s.login.Token
+	s.mutator.SetValue(&token)
+	return s.mutator.Apply(req)
+}
+
+// Reports whether the token expires within the refresh margin
+func (s *Session) expiringLocked() bool {
+	if s.expires.IsZero() {
+		return false
+	}
+	margin := s.refreshBefore
+	if lifetime := s.expires.Sub(s.loggedIn); margin > lifetime/2 {
+		margin = lifetime / 2
+	}
+	return !time.Now().Add(margin).Before(s.expires)
+}
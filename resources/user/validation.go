@@ -0,0 +1,41 @@
+package user
+
+import (
+	sdkcore "pets_go/core"
+)
+
+// Checks the request against the constraints of the API schema
+func (r DeleteRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	v.Check(r.Username != "", "username", "is required")
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r GetRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	v.Check(r.Username != "", "username", "is required")
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r CreateRequest) Validate() error {
+	return r.ToUser().Validate()
+}
+
+// Checks the request against the constraints of the API schema
+func (r CreateWithListRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	for i, user := range r.Data {
+		user.ValidateAt(v, sdkcore.IndexPath("data", i))
+	}
+	return v.Err()
+}
+
+// Checks the request against the constraints of the API schema
+func (r UpdateRequest) Validate() error {
+	v := &sdkcore.Validator{}
+	v.Check(r.CurrentUsername != "", "currentUsername", "is required")
+	r.ToUser().ValidateAt(v, "")
+	return v.Err()
+}
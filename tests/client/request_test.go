@@ -0,0 +1,102 @@
+package test_client
+
+import (
+	errors "errors"
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	types "pets_go/types"
+	strings "strings"
+	testing "testing"
+)
+
+func TestDo(t *testing.T) {
+	var method, path, query, apiKey, contentType, trace, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		method, path, query, body = r.Method, r.URL.EscapedPath(), r.URL.RawQuery, string(data)
+		apiKey, contentType, trace = r.Header.Get("api_key"), r.Header.Get("Content-Type"), r.Header.Get("X-Trace")
+		if strings.HasSuffix(path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		io.WriteString(w, `<pet><id>10</id><name>doggie</name><photoUrls><photoUrl>https://a/1.png</photoUrl></photoUrls></pet>`)
+	}))
+	defer server.Close()
+	client := sdk.NewClient(
+		sdk.WithBaseURL(server.URL),
+		sdk.WithApiKey("secret"),
+		sdk.WithModifiers(func(req *http.Request) error {
+			req.Header.Set("X-Trace", "client")
+			return nil
+		}),
+	)
+
+	var pet types.Pet
+	res, err := client.Do(sdk.Request{
+		Method:     "POST",
+		Path:       "/pet/{petId}/clone/{name}",
+		PathParams: map[string]interface{}{"petId": 10, "name": "a b/c"},
+		Query: map[string]interface{}{
+			"tags":  []string{"x", "y"},
+			"note":  nullable.Nullable[string]{},
+			"count": 2,
+		},
+		Body: types.Pet{Name: "doggie", PhotoUrls: []string{}},
+		Auth: []string{"api_key"},
+	}, &pet)
+	if err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("TestDo - failed with error: %#v", err)
+	}
+	if pet.Id.OrZero() != 10 || pet.Name != "doggie" || len(pet.PhotoUrls) != 1 {
+		t.Fatalf("TestDo - unexpected decoded pet %+v", pet)
+	}
+	if method != "POST" || path != "/pet/10/clone/a%20b%2Fc" || query != "count=2&tags=x&tags=y" {
+		t.Fatalf("TestDo - unexpected request %s %s?%s", method, path, query)
+	}
+	if apiKey != "secret" || contentType != "application/json" || trace != "client" || body != `{"name":"doggie","photoUrls":[]}` {
+		t.Fatalf("TestDo - unexpected api key %q, content type %q, trace %q or body %s", apiKey, contentType, trace, body)
+	}
+
+	// without auth schemes no credentials are sent, request modifiers apply last
+	res, err = client.Do(sdk.Request{Path: "/pet/10", QueryArrayEncoding: sdkcore.ArrayEncodingComma, Query: map[string]interface{}{"tags": []string{"x", "y"}}}, nil,
+		func(req *http.Request) error {
+			req.Header.Set("X-Trace", "request")
+			return nil
+		})
+	if err != nil || method != "GET" || apiKey != "" || trace != "request" || query != "tags=x%2Cy" {
+		t.Fatalf("TestDo - unexpected request %s ?%s with api key %q, trace %q (%v)", method, query, apiKey, trace, err)
+	}
+	// a nil out leaves the body to the caller
+	res.Body.Close()
+
+	var apiErr sdkcore.ApiError
+	if _, err := client.Do(sdk.Request{Path: "/missing"}, &pet); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("TestDo - expected not found error, got %#v", err)
+	}
+}
+
+func TestRequestPathParams(t *testing.T) {
+	client := sdk.NewClient(sdk.WithBaseURL("https://example.com/v1"))
+
+	req, err := client.Request(sdk.Request{Method: "DELETE", Path: "/user/{username}", PathParams: map[string]interface{}{"username": "the user"}})
+	if err != nil || req.URL.String() != "https://example.com/v1/user/the%20user" || req.Method != "DELETE" {
+		t.Fatalf("TestRequestPathParams - unexpected request %v (%v)", req, err)
+	}
+
+	invalid := []sdk.Request{
+		{},
+		{Path: "/user/{username}"},
+		{Path: "/user/{username", PathParams: map[string]interface{}{"username": "a"}},
+		{Path: "/user/{username}", PathParams: map[string]interface{}{"username": "a", "usrname": "b"}},
+	}
+	for _, request := range invalid {
+		if _, err := client.Request(request); err == nil {
+			t.Fatalf("TestRequestPathParams - expected error for path %q", request.Path)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package test_core
+
+import (
+	json "encoding/json"
+	io "io"
+	multipart "mime/multipart"
+	http "net/http"
+	httptest "net/http/httptest"
+	url "net/url"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	pet "pets_go/resources/pet"
+	types "pets_go/types"
+	strings "strings"
+	testing "testing"
+)
+
+// Wraps the JSON codec, counting its calls
+type countingCodec struct {
+	encoded *int
+	decoded *int
+}
+
+func (c countingCodec) Encode(body interface{}, options sdkcore.EncodeOptions) (io.Reader, string, error) {
+	*c.encoded++
+	return sdkcore.JSONCodec{}.Encode(body, options)
+}
+
+func (c countingCodec) Decode(data []byte, contentType string, v interface{}) error {
+	*c.decoded++
+	return json.Unmarshal(data, v)
+}
+
+func TestCustomCodec(t *testing.T) {
+	var accept, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept, contentType = r.Header.Get("Accept"), r.Header.Get("Content-Type")
+	}))
+	defer server.Close()
+
+	encoded, decoded := 0, 0
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithCodec("application/json", countingCodec{&encoded, &decoded}))
+	if _, err := client.Pet.Create(pet.CreateRequest{Name: "doggie", PhotoUrls: []string{}}); err != nil {
+		t.Fatalf("TestCustomCodec - create failed with error: %#v", err)
+	}
+	if encoded != 1 || contentType != "application/json" || accept != "application/json, application/xml" {
+		t.Fatalf("TestCustomCodec - unexpected %d encodes, content type %q & accept %q", encoded, contentType, accept)
+	}
+
+	// the JSON codec also serves +json media types & bodies without a codec
+	core := sdkcore.NewCoreClient(sdkcore.DefaultBaseURL(""))
+	core.RegisterCodec("application/json", countingCodec{&encoded, &decoded})
+	var tag types.Tag
+	for _, responseType := range []string{"application/problem+json", "text/plain", ""} {
+		if err := core.DecodeBody(responseType, []byte(`{"id":1}`), &tag); err != nil || tag.Id.OrZero() != 1 {
+			t.Fatalf("TestCustomCodec - failed decoding %q with error: %v", responseType, err)
+		}
+	}
+	if decoded != 3 {
+		t.Fatalf("TestCustomCodec - expected 3 decodes, got %d", decoded)
+	}
+
+	if _, _, err := core.EncodeBody(tag, "text/csv", sdkcore.EncodeOptions{}); err == nil {
+		t.Fatalf("TestCustomCodec - expected error encoding without a codec")
+	}
+	if accepted := core.Accept("text/csv", sdkcore.ContentTypeXML); accepted != "application/xml" {
+		t.Fatalf("TestCustomCodec - unexpected accept %q", accepted)
+	}
+}
+
+func TestBuiltInCodecs(t *testing.T) {
+	core := sdkcore.NewCoreClient(sdkcore.DefaultBaseURL(""))
+	tag := types.Tag{Id: nullable.NewValue(1), Name: nullable.NewValue("good boy")}
+
+	body, contentType, err := core.EncodeBody(tag, sdkcore.ContentTypeFormUrlEncoded, sdkcore.EncodeOptions{})
+	if err != nil {
+		t.Fatalf("TestBuiltInCodecs - failed form encoding with error: %#v", err)
+	}
+	data, _ := io.ReadAll(body)
+	var values url.Values
+	if err := core.DecodeBody(contentType, data, &values); err != nil || values.Get("name") != "good boy" || values.Get("id") != "1" {
+		t.Fatalf("TestBuiltInCodecs - unexpected form values %v (%v)", values, err)
+	}
+
+	body, contentType, err = core.EncodeBody(tag, sdkcore.ContentTypeMultipart, sdkcore.EncodeOptions{})
+	if err != nil || !strings.HasPrefix(contentType, "multipart/form-data; boundary=") {
+		t.Fatalf("TestBuiltInCodecs - unexpected multipart content type %q (%v)", contentType, err)
+	}
+	data, _ = io.ReadAll(body)
+	var form multipart.Form
+	if err := core.DecodeBody(contentType, data, &form); err != nil || form.Value["name"][0] != "good boy" || form.Value["id"][0] != "1" {
+		t.Fatalf("TestBuiltInCodecs - unexpected multipart form %v (%v)", form.Value, err)
+	}
+
+	body, contentType, err = core.EncodeBody(sdkcore.NewFileFromBytes("a.txt", []byte("hello")), sdkcore.ContentTypeOctetStream, sdkcore.EncodeOptions{})
+	if err != nil || contentType != sdkcore.ContentTypeOctetStream {
+		t.Fatalf("TestBuiltInCodecs - unexpected octet-stream content type %q (%v)", contentType, err)
+	}
+	data, _ = io.ReadAll(body)
+	var text string
+	if err := core.DecodeBody(contentType, data, &text); err != nil || text != "hello" {
+		t.Fatalf("TestBuiltInCodecs - unexpected octet-stream body %q (%v)", text, err)
+	}
+}
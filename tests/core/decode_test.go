@@ -0,0 +1,94 @@
+package test_core
+
+import (
+	json "encoding/json"
+	errors "errors"
+	sdkcore "pets_go/core"
+	types "pets_go/types"
+	testing "testing"
+)
+
+func TestEnumDecodePolicies(t *testing.T) {
+	body := []byte(`[{"name":"a","photoUrls":[],"status":"sold"},{"name":"b","photoUrls":[],"status":"adopted"}]`)
+
+	lenient := sdkcore.NewCoreClient(sdkcore.DefaultBaseURL(""))
+	var pets []types.Pet
+	if err := lenient.DecodeJSON(body, &pets); err != nil {
+		t.Fatalf("TestEnumDecodePolicies - lenient decoding failed with error: %#v", err)
+	}
+	// unknown values are kept and flagged by IsValid
+	if status := pets[1].Status.OrZero(); status != "adopted" || status.IsValid() {
+		t.Fatalf("TestEnumDecodePolicies - unexpected lenient status %q", status)
+	}
+
+	strict := sdkcore.NewCoreClient(sdkcore.DefaultBaseURL(""))
+	strict.EnumPolicy = sdkcore.EnumPolicyStrict
+	var enumErr sdkcore.UnknownEnumError
+	if err := strict.DecodeJSON(body, &pets); !errors.As(err, &enumErr) || enumErr.Path != "[1].status" || enumErr.Value != "adopted" {
+		t.Fatalf("TestEnumDecodePolicies - expected unknown enum error, got %#v", err)
+	}
+}
+
+func TestEnumHelpers(t *testing.T) {
+	if values := types.OrderStatusEnumValues(); len(values) != 3 || values[0] != types.OrderStatusEnumApproved {
+		t.Fatalf("TestEnumHelpers - unexpected values %v", values)
+	}
+	if status, err := types.ParsePetStatusEnum("pending"); err != nil || status != types.PetStatusEnumPending {
+		t.Fatalf("TestEnumHelpers - failed parsing valid value: %v", err)
+	}
+	if _, err := types.ParsePetFindByStatusStatusEnum("adopted"); err == nil {
+		t.Fatalf("TestEnumHelpers - expected error parsing unknown value")
+	}
+	if types.PetStatusEnumSold.ToFindByStatus() != types.PetFindByStatusStatusEnumSold ||
+		types.PetFindByStatusStatusEnumAvailable.ToPetStatus() != types.PetStatusEnumAvailable {
+		t.Fatalf("TestEnumHelpers - unexpected conversions")
+	}
+}
+
+func TestUnknownFieldsRoundTrip(t *testing.T) {
+	body := []byte(`{"name":"doggie","photoUrls":[],"microchip":{"id":"985112"},"category":{"id":1,"breed":"beagle"}}`)
+
+	lenient := sdkcore.NewCoreClient(sdkcore.DefaultBaseURL(""))
+	var pet types.Pet
+	if err := lenient.DecodeJSON(body, &pet); err != nil {
+		t.Fatalf("TestUnknownFieldsRoundTrip - lenient decoding failed with error: %#v", err)
+	}
+	if string(pet.AdditionalProperties["microchip"]) != `{"id":"985112"}` || len(pet.AdditionalProperties) != 1 {
+		t.Fatalf("TestUnknownFieldsRoundTrip - unexpected additional properties %v", pet.AdditionalProperties)
+	}
+	if category := pet.Category.OrZero(); string(category.AdditionalProperties["breed"]) != `"beagle"` {
+		t.Fatalf("TestUnknownFieldsRoundTrip - nested unknown property was not captured: %v", category.AdditionalProperties)
+	}
+
+	encoded, err := json.Marshal(pet)
+	if err != nil {
+		t.Fatalf("TestUnknownFieldsRoundTrip - failed encoding with error: %#v", err)
+	}
+	expected := `{"category":{"id":1,"breed":"beagle"},"name":"doggie","photoUrls":[],"microchip":{"id":"985112"}}`
+	if string(encoded) != expected {
+		t.Fatalf("TestUnknownFieldsRoundTrip - expected %s, got %s", expected, encoded)
+	}
+
+	// known fields are matched case-insensitively like encoding/json does
+	var tag types.Tag
+	if err := json.Unmarshal([]byte(`{"ID":1,"Name":"x"}`), &tag); err != nil || tag.AdditionalProperties != nil {
+		t.Fatalf("TestUnknownFieldsRoundTrip - unexpected additional properties %v (%v)", tag.AdditionalProperties, err)
+	}
+	// escaped names, and strings holding quotes & brackets, don't throw off the key scan
+	spaced := []byte(" {\n\t\"\\u0069d\" : 2 , \"note\\\"s\": \"a \\\"}\" ,\"extra\":[{\"name\":\"]\"}, -1.5e3, null] }\n")
+	if err := json.Unmarshal(spaced, &tag); err != nil || tag.Id.OrZero() != 2 || len(tag.AdditionalProperties) != 2 ||
+		string(tag.AdditionalProperties[`note"s`]) != `"a \"}"` || string(tag.AdditionalProperties["extra"]) != `[{"name":"]"}, -1.5e3, null]` {
+		t.Fatalf("TestUnknownFieldsRoundTrip - unexpected additional properties %v (%v)", tag.AdditionalProperties, err)
+	}
+
+	strict := sdkcore.NewCoreClient(sdkcore.DefaultBaseURL(""))
+	strict.StrictDecoding = true
+	var fieldErr sdkcore.UnknownFieldError
+	if err := strict.DecodeJSON([]byte(`[{"name":"a","photoUrls":[]},{"name":"b","photoUrls":[],"category":{"breed":"beagle"}}]`), &[]types.Pet{}); !errors.As(err, &fieldErr) ||
+		fieldErr.Path != "[1].category" || fieldErr.Field != "breed" || fieldErr.Type != "Category" {
+		t.Fatalf("TestUnknownFieldsRoundTrip - expected unknown field error, got %#v", err)
+	}
+	if err := strict.DecodeJSON([]byte(`{"name":"a","photoUrls":[]}`), &types.Pet{}); err != nil {
+		t.Fatalf("TestUnknownFieldsRoundTrip - strict decoding of known fields failed with error: %#v", err)
+	}
+}
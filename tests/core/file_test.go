@@ -0,0 +1,52 @@
+package test_core
+
+import (
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdkcore "pets_go/core"
+	strings "strings"
+	testing "testing"
+	fstest "testing/fstest"
+)
+
+func TestFileBodyIsReplayedOnRedirect(t *testing.T) {
+	fsys := fstest.MapFS{"dog.jpg": &fstest.MapFile{Data: []byte("fs content")}}
+	fsFile, _ := sdkcore.NewFileFromFS(fsys, "dog.jpg")
+
+	files := map[string]sdkcore.File{
+		"bytes":  sdkcore.NewFileFromBytes("dog.jpg", []byte("bytes content")),
+		"fs":     fsFile,
+		"seeker": sdkcore.NewFile("dog.jpg", strings.NewReader("seeker content")),
+	}
+	expected := map[string]string{"bytes": "bytes content", "fs": "fs content", "seeker": "seeker content"}
+
+	for name, file := range files {
+		var received []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received = append(received, string(body))
+			if r.URL.Path == "/first" {
+				http.Redirect(w, r, "/second", http.StatusTemporaryRedirect)
+			}
+		}))
+
+		req, _ := http.NewRequest("POST", server.URL+"/first", nil)
+		if err := sdkcore.SetFileBody(req, file); err != nil {
+			t.Fatalf("TestFileBodyIsReplayedOnRedirect - %s failed setting body with error: %#v", name, err)
+		}
+		if req.ContentLength != int64(len(expected[name])) || req.Header.Get("Content-Type") != "image/jpeg" {
+			t.Fatalf("TestFileBodyIsReplayedOnRedirect - %s has unexpected length %d or type %s", name, req.ContentLength, req.Header.Get("Content-Type"))
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("TestFileBodyIsReplayedOnRedirect - %s failed making request with error: %#v", name, err)
+		}
+		resp.Body.Close()
+		server.Close()
+
+		if len(received) != 2 || received[0] != expected[name] || received[1] != expected[name] {
+			t.Fatalf("TestFileBodyIsReplayedOnRedirect - %s bodies received %#v", name, received)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package test_core
+
+import (
+	errors "errors"
+	io "io"
+	fs "io/fs"
+	mime "mime"
+	multipart "mime/multipart"
+	http "net/http"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	runtime "runtime"
+	strings "strings"
+	atomic "sync/atomic"
+	testing "testing"
+	fstest "testing/fstest"
+)
+
+func TestMultipartBodyStreamsParts(t *testing.T) {
+	fsys := fstest.MapFS{"photos/dog.png": &fstest.MapFile{Data: []byte("\x89PNG\r\n\x1a\nrest")}}
+	pngFile, err := sdkcore.NewFileFromFS(fsys, "photos/dog.png")
+	if err != nil {
+		t.Fatalf("TestMultipartBodyStreamsParts - failed opening file with error: %#v", err)
+	}
+
+	body, contentType := sdkcore.MultipartBody(
+		sdkcore.FormDataField{Name: "image", Value: pngFile},
+		sdkcore.FormDataField{Name: "notes", Value: sdkcore.File{Filename: "notes.bin", Content: strings.NewReader("hello"), ContentType: "text/markdown"}},
+		sdkcore.FormDataField{Name: "tags", Value: []string{"a", "b"}},
+		sdkcore.FormDataField{Name: "skipped", Value: nullable.Nullable[string]{}},
+	)
+	defer body.Close()
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		t.Fatalf("TestMultipartBodyStreamsParts - unexpected content type %s", contentType)
+	}
+
+	reader := multipart.NewReader(body, params["boundary"])
+	expected := []struct{ name, filename, contentType, content string }{
+		{"image", "dog.png", "image/png", "\x89PNG\r\n\x1a\nrest"},
+		{"notes", "notes.bin", "text/markdown", "hello"},
+		{"tags", "", "", "a"},
+		{"tags", "", "", "b"},
+	}
+	for _, exp := range expected {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("TestMultipartBodyStreamsParts - failed reading part with error: %#v", err)
+		}
+		content, _ := io.ReadAll(part)
+		if part.FormName() != exp.name || part.FileName() != exp.filename ||
+			part.Header.Get("Content-Type") != exp.contentType || string(content) != exp.content {
+			t.Fatalf("TestMultipartBodyStreamsParts - unexpected part %s %s %s %q",
+				part.FormName(), part.FileName(), part.Header.Get("Content-Type"), content)
+		}
+	}
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Fatalf("TestMultipartBodyStreamsParts - expected end of body, got %#v", err)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("disk on fire") }
+
+func TestMultipartBodySurfacesWriteErrors(t *testing.T) {
+	body, _ := sdkcore.MultipartBody(
+		sdkcore.FormDataField{Name: "file", Value: sdkcore.NewFile("broken.bin", failingReader{})},
+	)
+	defer body.Close()
+
+	if _, err := io.ReadAll(body); err == nil || err.Error() != "disk on fire" {
+		t.Fatalf("TestMultipartBodySurfacesWriteErrors - expected write error, got %#v", err)
+	}
+}
+
+// Counts the files opened, entries are stat'ed without opening them
+type countingFS struct {
+	fstest.MapFS
+	opens *int32
+}
+
+func (f countingFS) Open(name string) (fs.File, error) {
+	atomic.AddInt32(f.opens, 1)
+	return f.MapFS.Open(name)
+}
+
+func TestMultipartBodyStartsOnRead(t *testing.T) {
+	var opens int32
+	fsys := countingFS{MapFS: fstest.MapFS{"dog.png": &fstest.MapFile{Data: []byte("\x89PNG\r\n\x1a\nrest")}}, opens: &opens}
+	file, err := sdkcore.NewFileFromFS(fsys, "dog.png")
+	if err != nil {
+		t.Fatalf("TestMultipartBodyStartsOnRead - failed opening file with error: %#v", err)
+	}
+
+	goroutines := runtime.NumGoroutine()
+	for i := 0; i < 20; i++ {
+		// bodies of requests failing before they are sent are closed or dropped unread
+		body, _ := sdkcore.MultipartBody(sdkcore.FormDataField{Name: "image", Value: file})
+		if i%2 == 0 {
+			body.Close()
+		}
+	}
+	if opens != 0 || runtime.NumGoroutine() > goroutines {
+		t.Fatalf("TestMultipartBodyStartsOnRead - unread bodies opened %d files and started %d goroutines", opens, runtime.NumGoroutine()-goroutines)
+	}
+
+	body, contentType := sdkcore.MultipartBody(sdkcore.FormDataField{Name: "image", Value: file})
+	_, params, _ := mime.ParseMediaType(contentType)
+	form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1 << 20)
+	if err != nil || len(form.File["image"]) != 1 || opens != 1 {
+		t.Fatalf("TestMultipartBodyStartsOnRead - unexpected form %v after %d opens (%v)", form, opens, err)
+	}
+	body.Close()
+	if _, err := body.Read(make([]byte, 1)); err == nil {
+		t.Fatalf("TestMultipartBodyStartsOnRead - expected error reading a closed body")
+	}
+}
+
+func TestFailingModifierLeavesBodiesUnopened(t *testing.T) {
+	var opens int32
+	fsys := countingFS{MapFS: fstest.MapFS{"dog.png": &fstest.MapFile{Data: []byte("\x89PNG\r\n\x1a\nrest")}}, opens: &opens}
+	file, err := sdkcore.NewFileFromFS(fsys, "dog.png")
+	if err != nil {
+		t.Fatalf("TestFailingModifierLeavesBodiesUnopened - failed opening file with error: %#v", err)
+	}
+	client := sdkcore.NewCoreClient(sdkcore.DefaultBaseURL("http://127.0.0.1:0"))
+
+	// the steps of an operation whose modifiers fail once its body is encoded
+	failing := errors.New("modifier failed")
+	goroutines := runtime.NumGoroutine()
+	for _, contentType := range []string{sdkcore.ContentTypeMultipart, sdkcore.ContentTypeOctetStream} {
+		body := interface{}(file)
+		if contentType == sdkcore.ContentTypeMultipart {
+			body = []sdkcore.FormDataField{{Name: "image", Value: file}}
+		}
+		reader, _, err := client.EncodeBody(body, contentType, sdkcore.EncodeOptions{})
+		if err != nil {
+			t.Fatalf("TestFailingModifierLeavesBodiesUnopened - failed encoding %s body with error: %#v", contentType, err)
+		}
+		req, err := http.NewRequest("POST", "http://127.0.0.1:0/upload", reader)
+		if err != nil {
+			t.Fatalf("TestFailingModifierLeavesBodiesUnopened - failed creating request with error: %#v", err)
+		}
+		err = client.ApplyModifiers(req, []sdkcore.RequestModifier{func(req *http.Request) error {
+			return failing
+		}})
+		if !errors.Is(err, failing) {
+			t.Fatalf("TestFailingModifierLeavesBodiesUnopened - expected modifier error, got %#v", err)
+		}
+	}
+	if opens != 0 || runtime.NumGoroutine() > goroutines {
+		t.Fatalf("TestFailingModifierLeavesBodiesUnopened - failed requests opened %d files and started %d goroutines",
+			opens, runtime.NumGoroutine()-goroutines)
+	}
+}
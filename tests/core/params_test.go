@@ -0,0 +1,65 @@
+package test_core
+
+import (
+	url "net/url"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	types "pets_go/types"
+	testing "testing"
+)
+
+type queryObject struct {
+	Zeta  string         `json:"zeta"`
+	Alpha int            `json:"alpha"`
+	Attrs map[string]int `json:"attrs"`
+}
+
+func TestQueryParamsAreCanonical(t *testing.T) {
+	// Map derived params must encode identically on every run
+	build := func() string {
+		params := url.Values{}
+		sdkcore.AddQueryParam(params, "status", nullable.NewValue(types.PetFindByStatusStatusEnumSold), "form", true)
+		sdkcore.AddQueryParam(params, "missing", nullable.Nullable[string]{}, "form", true)
+		sdkcore.AddQueryParam(params, "m", map[string]int{"c": 3, "a": 1, "b": 2}, "form", false)
+		sdkcore.AddQueryParam(params, "obj", queryObject{Zeta: "z z", Alpha: 1}, "form", false)
+		sdkcore.AddQueryParam(params, "deep", map[string]int{"y": 1, "x": 2}, "deepObject", true)
+		return sdkcore.CanonicalQueryString(params)
+	}
+
+	expected := "deep%5Bx%5D=2&deep%5By%5D=1&m=a%2C1%2Cb%2C2%2Cc%2C3&obj=zeta%2Cz%20z%2Calpha%2C1%2Cattrs%2Cnull&status=sold"
+	for i := 0; i < 20; i++ {
+		if got := build(); got != expected {
+			t.Fatalf("TestQueryParamsAreCanonical - expected %s, got %s", expected, got)
+		}
+	}
+}
+
+func TestFormUrlEncodedBodyKeepsFieldOrder(t *testing.T) {
+	// Struct fields are written in declared order rather than sorted
+	body, err := sdkcore.FormUrlEncodedBody(types.Order{
+		Status:   nullable.NewValue(types.OrderStatusEnumPlaced),
+		Quantity: nullable.NewValue(3),
+		Complete: nullable.NewValue(true),
+	}, map[string]string{}, map[string]bool{})
+	if err != nil {
+		t.Fatalf("TestFormUrlEncodedBodyKeepsFieldOrder - failed encoding body with error: %#v", err)
+	}
+
+	buf := make([]byte, body.Len())
+	body.Read(buf)
+	if expected := "complete=true&quantity=3&status=placed"; string(buf) != expected {
+		t.Fatalf("TestFormUrlEncodedBodyKeepsFieldOrder - expected %s, got %s", expected, string(buf))
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	u, _ := url.Parse("https://example.com/pet?status=sold&additionalMetadata=a+b&status=available")
+	canonical, err := sdkcore.CanonicalURL(u)
+	if err != nil {
+		t.Fatalf("TestCanonicalURL - failed with error: %#v", err)
+	}
+
+	if expected := "https://example.com/pet?additionalMetadata=a%20b&status=sold&status=available"; canonical != expected {
+		t.Fatalf("TestCanonicalURL - expected %s, got %s", expected, canonical)
+	}
+}
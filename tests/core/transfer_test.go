@@ -0,0 +1,71 @@
+package test_core
+
+import (
+	bytes "bytes"
+	context "context"
+	errors "errors"
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdkcore "pets_go/core"
+	testing "testing"
+	time "time"
+)
+
+func newDiscardServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}))
+}
+
+func TestUploadProgressAndRateLimit(t *testing.T) {
+	server := newDiscardServer()
+	defer server.Close()
+
+	var updates []sdkcore.UploadProgress
+	req, _ := http.NewRequest("POST", server.URL, bytes.NewReader(make([]byte, 400)))
+	for _, mod := range []sdkcore.RequestModifier{
+		sdkcore.WithUploadRateLimit(1000),
+		sdkcore.WithUploadProgress(func(p sdkcore.UploadProgress) { updates = append(updates, p) }),
+	} {
+		mod(req)
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("TestUploadProgressAndRateLimit - failed making request with error: %#v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Fatalf("TestUploadProgressAndRateLimit - 400 bytes at 1000B/s sent in %s", elapsed)
+	}
+	if len(updates) < 4 {
+		t.Fatalf("TestUploadProgressAndRateLimit - expected progress per chunk, got %#v", updates)
+	}
+	if last := updates[len(updates)-1]; last.BytesSent != 400 || last.Total != 400 || last.Rate <= 0 {
+		t.Fatalf("TestUploadProgressAndRateLimit - unexpected final progress %#v", last)
+	}
+}
+
+func TestUploadAbortedByContext(t *testing.T) {
+	server := newDiscardServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequest("POST", server.URL, bytes.NewReader(make([]byte, 1000)))
+	sdkcore.WithUploadRateLimit(10)(req)
+	sdkcore.WithContext(ctx)(req)
+
+	start := time.Now()
+	_, err := http.DefaultClient.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("TestUploadAbortedByContext - expected deadline exceeded, got %#v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("TestUploadAbortedByContext - stalled upload took %s to abort", elapsed)
+	}
+}
@@ -0,0 +1,82 @@
+package test_nullable
+
+import (
+	json "encoding/json"
+	nullable "pets_go/nullable"
+	types "pets_go/types"
+	testing "testing"
+)
+
+func TestPetMergePatch(t *testing.T) {
+	original := types.Pet{
+		Id:        nullable.NewValue(10),
+		Name:      "doggie",
+		PhotoUrls: []string{"a"},
+		Category:  nullable.NewValue(types.Category{Id: nullable.NewValue(1), Name: nullable.NewValue("Dogs")}),
+		Status:    nullable.NewValue(types.PetStatusEnumAvailable),
+		Tags:      nullable.NewValue([]types.Tag{{Id: nullable.NewValue(1)}}),
+	}
+	modified := original.Clone()
+	modified.Status.SetUndefined()
+	modified.Category = nullable.NewValue(types.Category{Id: nullable.NewValue(1), Name: nullable.NewValue("Cats")})
+	modified.Tags = nullable.NewValue([]types.Tag{{Id: nullable.NewValue(1)}, {Id: nullable.NewValue(2)}})
+
+	patch, err := types.DiffPet(original, modified)
+	if err != nil {
+		t.Fatalf("TestPetMergePatch - failed diffing with error: %#v", err)
+	}
+
+	doc, _ := json.Marshal(patch)
+	expected := `{"category":{"name":"Cats"},"status":null,"tags":[{"id":1},{"id":2}]}`
+	if string(doc) != expected {
+		t.Fatalf("TestPetMergePatch - expected patch %s, got %s", expected, doc)
+	}
+
+	applied, err := patch.Apply(original)
+	if err != nil {
+		t.Fatalf("TestPetMergePatch - failed applying with error: %#v", err)
+	}
+	if !applied.Equal(modified) {
+		t.Fatalf("TestPetMergePatch - expected %+v, got %+v", modified, applied)
+	}
+
+	if unchanged, _ := types.DiffPet(original, original.Clone()); !unchanged.IsEmpty() {
+		t.Fatalf("TestPetMergePatch - expected empty patch, got %+v", unchanged)
+	}
+}
+
+func TestOrderMergePatch(t *testing.T) {
+	original := types.Order{Id: nullable.NewValue(1), Quantity: nullable.NewValue(2), Complete: nullable.NewValue(false)}
+
+	patch := types.OrderPatch{Quantity: nullable.NewValue(5), Complete: nullable.NewNull[bool]()}
+	applied, err := patch.Apply(original)
+	if err != nil {
+		t.Fatalf("TestOrderMergePatch - failed applying with error: %#v", err)
+	}
+
+	// null removes the field, leaving it undefined
+	expected := types.Order{Id: nullable.NewValue(1), Quantity: nullable.NewValue(5)}
+	if !applied.Equal(expected) {
+		t.Fatalf("TestOrderMergePatch - expected %+v, got %+v", expected, applied)
+	}
+
+	diffed, _ := types.DiffOrder(original, applied)
+	if doc, _ := json.Marshal(diffed); string(doc) != `{"complete":null,"quantity":5}` {
+		t.Fatalf("TestOrderMergePatch - unexpected diff %s", doc)
+	}
+}
+
+func TestApplyMergePatchRFCExample(t *testing.T) {
+	// example from RFC 7396 section 3
+	document := `{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"],"content":"This will be unchanged"}`
+	patch := `{"title":"Hello!","phoneNumber":"+01-123-456-7890","author":{"familyName":null},"tags":["example"]}`
+
+	result, err := nullable.ApplyMergePatch([]byte(document), []byte(patch))
+	if err != nil {
+		t.Fatalf("TestApplyMergePatchRFCExample - failed with error: %#v", err)
+	}
+	expected := `{"author":{"givenName":"John"},"content":"This will be unchanged","phoneNumber":"+01-123-456-7890","tags":["example"],"title":"Hello!"}`
+	if string(result) != expected {
+		t.Fatalf("TestApplyMergePatchRFCExample - expected %s, got %s", expected, result)
+	}
+}
@@ -0,0 +1,233 @@
+package test_nullable
+
+import (
+	json "encoding/json"
+	fmt "fmt"
+	nullable "pets_go/nullable"
+	types "pets_go/types"
+	strconv "strconv"
+	testing "testing"
+)
+
+func TestNullableJSONStates(t *testing.T) {
+	category := types.Category{
+		Id:   nullable.NewValue(1),
+		Name: nullable.NewNull[string](),
+	}
+
+	data, err := json.Marshal(types.Pet{Name: "doggie", Category: nullable.NewValue(category)})
+	if err != nil {
+		t.Fatalf("TestNullableJSONStates - failed marshaling with error: %#v", err)
+	}
+	if expected := `{"category":{"id":1,"name":null},"name":"doggie","photoUrls":null}`; string(data) != expected {
+		t.Fatalf("TestNullableJSONStates - expected %s, got %s", expected, data)
+	}
+
+	var decoded types.Category
+	if err := json.Unmarshal([]byte(`{"name":null}`), &decoded); err != nil {
+		t.Fatalf("TestNullableJSONStates - failed unmarshaling with error: %#v", err)
+	}
+	if !decoded.Id.IsUndefined() || !decoded.Name.IsNull() {
+		t.Fatalf("TestNullableJSONStates - unexpected states %#v", decoded)
+	}
+}
+
+func TestNullableCopiesDoNotAlias(t *testing.T) {
+	original := types.Tag{Id: nullable.NewValue(1)}
+	copied := original
+	copied.Id.Set(2)
+
+	if id, _ := original.Id.Value(); id != 1 {
+		t.Fatalf("TestNullableCopiesDoNotAlias - original changed to %d", id)
+	}
+}
+
+func TestNullableHelpers(t *testing.T) {
+	value, null, undefined := nullable.NewValue(3), nullable.NewNull[int](), nullable.Nullable[int]{}
+
+	if *value.Ptr() != 3 || null.Ptr() != nil || undefined.Ptr() != nil {
+		t.Fatalf("TestNullableHelpers - unexpected Ptr results")
+	}
+	if value.OrElse(7) != 3 || null.OrElse(7) != 7 || undefined.OrZero() != 0 {
+		t.Fatalf("TestNullableHelpers - unexpected OrElse/OrZero results")
+	}
+	five := 5
+	if !nullable.FromPtr(&five).Equal(nullable.NewValue(5)) || !nullable.FromPtr[int](nil).IsNull() {
+		t.Fatalf("TestNullableHelpers - unexpected FromPtr results")
+	}
+	if !value.Equal(nullable.NewValue(3)) || value.Equal(null) || null.Equal(undefined) || !undefined.Equal(nullable.Nullable[int]{}) {
+		t.Fatalf("TestNullableHelpers - unexpected Equal results")
+	}
+
+	mapped := nullable.Map(value, strconv.Itoa)
+	if v, _ := mapped.Value(); v != "3" || !nullable.Map(null, strconv.Itoa).IsNull() || !nullable.Map(undefined, strconv.Itoa).IsUndefined() {
+		t.Fatalf("TestNullableHelpers - unexpected Map results")
+	}
+}
+
+func TestNullableFormatting(t *testing.T) {
+	tag := types.Tag{Id: nullable.NewValue(7), Name: nullable.NewNull[string]()}
+	if got := fmt.Sprintf("%v", tag); got != "{7 null map[]}" {
+		t.Fatalf("TestNullableFormatting - unexpected %%v output %s", got)
+	}
+	if got := fmt.Sprintf("%+v", types.Category{}); got != "{Id:undefined Name:undefined AdditionalProperties:map[]}" {
+		t.Fatalf("TestNullableFormatting - unexpected %%+v output %s", got)
+	}
+	if got := fmt.Sprintf("%05.1f", nullable.NewValue(2.25)); got != "002.2" {
+		t.Fatalf("TestNullableFormatting - unexpected %%05.1f output %s", got)
+	}
+	// the raw message type prints as json.RawMessage or, where it is an alias, jsontext.Value
+	expected := `types.Tag{Id:nullable.NewValue[int](7), Name:nullable.NewNull[string](), AdditionalProperties:` +
+		fmt.Sprintf("%#v", map[string]json.RawMessage(nil)) + `}`
+	if got := fmt.Sprintf("%#v", tag); got != expected {
+		t.Fatalf("TestNullableFormatting - unexpected %%#v output %s", got)
+	}
+}
+
+func TestModelCloneAndEqual(t *testing.T) {
+	original := types.Pet{
+		Name:      "doggie",
+		PhotoUrls: []string{"a"},
+		Tags:      nullable.NewValue([]types.Tag{{Id: nullable.NewValue(1)}}),
+	}
+	clone := original.Clone()
+	if !clone.Equal(original) {
+		t.Fatalf("TestModelCloneAndEqual - clone differs from original")
+	}
+
+	clone.PhotoUrls[0] = "b"
+	tags, _ := clone.Tags.Value()
+	tags[0].Id.Set(2)
+	if original.PhotoUrls[0] != "a" {
+		t.Fatalf("TestModelCloneAndEqual - clone shares PhotoUrls with original")
+	}
+	if originalTags, _ := original.Tags.Value(); originalTags[0].Id.OrZero() != 1 {
+		t.Fatalf("TestModelCloneAndEqual - clone shares Tags with original")
+	}
+	if clone.Equal(original) {
+		t.Fatalf("TestModelCloneAndEqual - modified clone still equal to original")
+	}
+}
+
+// legacyNullable is the previous map based representation, kept for comparison
+type legacyNullable[T any] map[bool]T
+
+func (n legacyNullable[T]) value() (T, bool) {
+	v, ok := n[true]
+	return v, ok
+}
+
+// package level sinks keep values from being stack allocated, as they would
+// not be when stored in long lived models
+var (
+	nullableSink nullable.Nullable[int]
+	legacySink   legacyNullable[int]
+	petSink      types.Pet
+)
+
+func BenchmarkNullableSetAndValue(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		nullableSink = nullable.NewValue(i)
+		if v, err := nullableSink.Value(); err != nil || v != i {
+			b.Fatal("unexpected value")
+		}
+	}
+}
+
+func BenchmarkLegacyMapNullableSetAndValue(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		legacySink = legacyNullable[int]{true: i}
+		if v, ok := legacySink.value(); !ok || v != i {
+			b.Fatal("unexpected value")
+		}
+	}
+}
+
+func BenchmarkPetConstruction(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		petSink = types.Pet{
+			Id:     nullable.NewValue(i),
+			Status: nullable.NewValue(types.PetStatusEnumAvailable),
+			Category: nullable.NewValue(types.Category{
+				Id:   nullable.NewValue(1),
+				Name: nullable.NewValue("Dogs"),
+			}),
+		}
+		if petSink.Id.IsUndefined() {
+			b.Fatal("unexpected state")
+		}
+	}
+}
+
+func BenchmarkPetUnmarshal(b *testing.B) {
+	data := []byte(`{"id":10,"name":"doggie","category":{"id":1,"name":"Dogs"},"photoUrls":["a"],"tags":[{"id":1,"name":"t"}],"status":"available"}`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var pet types.Pet
+		if err := json.Unmarshal(data, &pet); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPetMarshal(b *testing.B) {
+	pet := types.Pet{
+		Id:        nullable.NewValue(10),
+		Name:      "doggie",
+		PhotoUrls: []string{"a"},
+		Status:    nullable.NewValue(types.PetStatusEnumAvailable),
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(pet); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type listingBase struct {
+	Id   int    `json:"id"`
+	Note string `json:"note"`
+}
+
+type ListingAudit struct {
+	Created string `json:"created"`
+}
+
+type listing struct {
+	listingBase
+	*ListingAudit
+	// shadows the promoted note
+	Note  string   `json:"note,omitempty"`
+	Name  string   `json:"name"`
+	Count int      `json:"count,string"`
+	Ready bool     `json:",string"`
+	Label string   `json:"label,string"`
+	Price *float64 `json:"price,string"`
+}
+
+func TestMarshalStructMatchesEncodingJSON(t *testing.T) {
+	price := 2.5
+	values := []listing{
+		{listingBase: listingBase{Id: 3, Note: "hidden"}, Name: "doggie", Count: 5, Label: `say "hi"`},
+		{listingBase: listingBase{Id: 4}, ListingAudit: &ListingAudit{Created: "today"}, Note: "shown", Ready: true, Price: &price},
+	}
+	for _, value := range values {
+		expected, _ := json.Marshal(value)
+		data, err := nullable.MarshalStruct(value)
+		if err != nil || string(data) != string(expected) {
+			t.Fatalf("TestMarshalStructMatchesEncodingJSON - expected %s, got %s (%v)", expected, data, err)
+		}
+
+		// promoted fields are known, a round trip keeps them and finds no additional property
+		var decoded listing
+		additional, err := nullable.UnmarshalStruct(data, &decoded)
+		if err != nil || additional != nil || decoded.Id != value.Id || decoded.Count != value.Count || decoded.Label != value.Label ||
+			(value.ListingAudit != nil && decoded.ListingAudit.Created != value.Created) {
+			t.Fatalf("TestMarshalStructMatchesEncodingJSON - round trip of %s gave %+v with additional %v (%v)", data, decoded, additional, err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package test_nullable
+
+import (
+	sql "database/sql"
+	driver "database/sql/driver"
+	flag "flag"
+	nullable "pets_go/nullable"
+	types "pets_go/types"
+	testing "testing"
+)
+
+var (
+	_ sql.Scanner   = &nullable.Nullable[int]{}
+	_ driver.Valuer = nullable.Nullable[int]{}.SQL()
+)
+
+func TestNullableScan(t *testing.T) {
+	var id nullable.Nullable[int]
+	if err := id.Scan(int64(42)); err != nil || id.OrZero() != 42 {
+		t.Fatalf("TestNullableScan - failed scanning int64: %v %v", id, err)
+	}
+
+	// SQL NULL is an explicit null, never undefined
+	if err := id.Scan(nil); err != nil || !id.IsNull() {
+		t.Fatalf("TestNullableScan - expected NULL to scan as null, got %v %v", id, err)
+	}
+
+	var status nullable.Nullable[types.PetStatusEnum]
+	if err := status.Scan([]byte("sold")); err != nil || status.OrZero() != types.PetStatusEnumSold {
+		t.Fatalf("TestNullableScan - failed scanning enum: %v %v", status, err)
+	}
+
+	var name nullable.Nullable[string]
+	if err := name.Scan("doggie"); err != nil || name.OrZero() != "doggie" {
+		t.Fatalf("TestNullableScan - failed scanning string: %v %v", name, err)
+	}
+
+	var category nullable.Nullable[types.Category]
+	if err := category.Scan(`{"id":1,"name":"Dogs"}`); err != nil || category.OrZero().Name.OrZero() != "Dogs" {
+		t.Fatalf("TestNullableScan - failed scanning JSON column: %v %v", category, err)
+	}
+
+	var small nullable.Nullable[int8]
+	if err := small.Scan(int64(1000)); err == nil {
+		t.Fatalf("TestNullableScan - expected overflow error, got %v", small)
+	}
+}
+
+func TestNullableDriverValue(t *testing.T) {
+	cases := []struct {
+		name     string
+		valuer   driver.Valuer
+		expected driver.Value
+	}{
+		{"int", nullable.NewValue(42).SQL(), int64(42)},
+		{"enum", nullable.NewValue(types.PetStatusEnumPending).SQL(), "pending"},
+		{"null", nullable.NewNull[string]().SQL(), nil},
+		// undefined cannot be represented in SQL and is written as NULL
+		{"undefined", nullable.Nullable[string]{}.SQL(), nil},
+	}
+	for _, c := range cases {
+		value, err := c.valuer.Value()
+		if err != nil || value != c.expected {
+			t.Fatalf("TestNullableDriverValue - %s expected %#v, got %#v (%v)", c.name, c.expected, value, err)
+		}
+	}
+
+	value, err := nullable.NewValue(types.Tag{Id: nullable.NewValue(1)}).DriverValue()
+	if err != nil || string(value.([]byte)) != `{"id":1}` {
+		t.Fatalf("TestNullableDriverValue - expected JSON for composite, got %#v (%v)", value, err)
+	}
+}
+
+func TestNullableText(t *testing.T) {
+	text, _ := nullable.NewValue(types.OrderStatusEnumDelivered).MarshalText()
+	if string(text) != "delivered" {
+		t.Fatalf("TestNullableText - unexpected text %s", text)
+	}
+	if text, _ := nullable.NewNull[int]().MarshalText(); len(text) != 0 {
+		t.Fatalf("TestNullableText - expected empty text for null, got %s", text)
+	}
+
+	// empty text is null, a Nullable UnmarshalText was never called on stays undefined
+	var quantity nullable.Nullable[int]
+	if err := quantity.UnmarshalText([]byte("")); err != nil || !quantity.IsNull() {
+		t.Fatalf("TestNullableText - expected empty text to be null, got %v", quantity)
+	}
+	if err := quantity.UnmarshalText([]byte("abc")); err == nil {
+		t.Fatalf("TestNullableText - expected parse error")
+	}
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	var petId, limit nullable.Nullable[int]
+	flags.TextVar(&petId, "pet-id", nullable.Nullable[int]{}, "")
+	flags.TextVar(&limit, "limit", nullable.Nullable[int]{}, "")
+	if err := flags.Parse([]string{"-pet-id=10"}); err != nil {
+		t.Fatalf("TestNullableText - failed parsing flags: %v", err)
+	}
+	if petId.OrZero() != 10 || !limit.IsUndefined() {
+		t.Fatalf("TestNullableText - unexpected flag values %v %v", petId, limit)
+	}
+}
@@ -0,0 +1,34 @@
+package test_pet_client
+
+import (
+	json "encoding/json"
+	nullable "pets_go/nullable"
+	pet "pets_go/resources/pet"
+	testutil "pets_go/tests/testutil"
+	types "pets_go/types"
+	testing "testing"
+)
+
+func TestPetConversionsCoverEveryField(t *testing.T) {
+	fixture := types.Pet{
+		Category:  nullable.NewValue(types.Category{Id: nullable.NewValue(1), Name: nullable.NewValue("Dogs")}),
+		Id:        nullable.NewValue(10),
+		Name:      "doggie",
+		PhotoUrls: []string{"string"},
+		Status:    nullable.NewValue(types.PetStatusEnumAvailable),
+		Tags:      nullable.NewValue([]types.Tag{{Id: nullable.NewValue(123)}}),
+
+		AdditionalProperties: map[string]json.RawMessage{"microchip": json.RawMessage(`"985112"`)},
+	}
+	testutil.AssertFullyPopulated(t, fixture)
+
+	testutil.AssertSameFields(t, types.Pet{}, pet.CreateRequest{}, "ContentType")
+	if roundTrip := pet.CreateRequestFromPet(fixture).ToPet(); !roundTrip.Equal(fixture) {
+		t.Fatalf("TestPetConversionsCoverEveryField - CreateRequest round trip lost fields: %+v", roundTrip)
+	}
+
+	testutil.AssertSameFields(t, types.Pet{}, pet.UpdateRequest{}, "ContentType")
+	if roundTrip := pet.UpdateRequestFromPet(fixture).ToPet(); !roundTrip.Equal(fixture) {
+		t.Fatalf("TestPetConversionsCoverEveryField - UpdateRequest round trip lost fields: %+v", roundTrip)
+	}
+}
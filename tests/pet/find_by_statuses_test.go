@@ -0,0 +1,144 @@
+package test_pet_client
+
+import (
+	context "context"
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	pet "pets_go/resources/pet"
+	types "pets_go/types"
+	strings "strings"
+	sync "sync"
+	testing "testing"
+	time "time"
+)
+
+// Serves pets 1 & 2 as available, 2 & 3 as pending (a pet changing status between
+// calls) and 4 as sold, recording the raw query of each call
+func newStatusServer(queries *[]string, mu *sync.Mutex) *httptest.Server {
+	byStatus := map[string][]int{"available": {1, 2}, "pending": {2, 3}, "sold": {4}}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*queries = append(*queries, r.URL.RawQuery)
+		mu.Unlock()
+
+		statuses := []string{}
+		for _, value := range r.URL.Query()["status"] {
+			statuses = append(statuses, strings.Split(value, ",")...)
+		}
+		if len(statuses) == 1 && statuses[0] == "broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		pets := []string{}
+		for _, status := range statuses {
+			for _, id := range byStatus[status] {
+				pets = append(pets, fmt.Sprintf(`{"id":%d,"name":"pet %d","photoUrls":[],"status":"%s"}`, id, id, status))
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, "["+strings.Join(pets, ",")+"]")
+	}))
+}
+
+func petIds(pets []types.Pet) []int {
+	ids := []int{}
+	for _, p := range pets {
+		ids = append(ids, p.Id.OrZero())
+	}
+	return ids
+}
+
+func TestFindByStatusesEncodings(t *testing.T) {
+	queries, mu := []string{}, &sync.Mutex{}
+	server := newStatusServer(&queries, mu)
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL))
+
+	pets, err := client.Pet.FindByStatuses(pet.FindByStatusRequest{
+		Status:   nullable.NewValue(types.PetFindByStatusStatusEnumAvailable),
+		Statuses: []types.PetFindByStatusStatusEnum{types.PetFindByStatusStatusEnumPending, types.PetFindByStatusStatusEnumAvailable},
+	}, pet.FindByStatusesOptions{})
+	if err != nil {
+		t.Fatalf("TestFindByStatusesEncodings - failed with error: %#v", err)
+	}
+	if ids := petIds(pets); fmt.Sprint(ids) != "[1 2 3]" || queries[0] != "status=available&status=pending" {
+		t.Fatalf("TestFindByStatusesEncodings - unexpected pets %v for query %s", ids, queries[0])
+	}
+
+	_, err = client.Pet.FindByStatuses(pet.FindByStatusRequest{
+		Statuses:       []types.PetFindByStatusStatusEnum{types.PetFindByStatusStatusEnumPending, types.PetFindByStatusStatusEnumSold},
+		StatusEncoding: sdkcore.ArrayEncodingComma,
+	}, pet.FindByStatusesOptions{})
+	if err != nil || queries[1] != "status=pending%2Csold" {
+		t.Fatalf("TestFindByStatusesEncodings - unexpected comma query %s (%v)", queries[1], err)
+	}
+
+	// a single status keeps the original encoding
+	if _, err := client.Pet.FindByStatus(pet.FindByStatusRequest{Status: nullable.NewValue(types.PetFindByStatusStatusEnumSold)}); err != nil || queries[2] != "status=sold" {
+		t.Fatalf("TestFindByStatusesEncodings - unexpected single status query %s (%v)", queries[2], err)
+	}
+}
+
+func TestFindAllFansOut(t *testing.T) {
+	queries, mu := []string{}, &sync.Mutex{}
+	server := newStatusServer(&queries, mu)
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL))
+
+	pets, err := client.Pet.FindAll()
+	if err != nil {
+		t.Fatalf("TestFindAllFansOut - failed with error: %#v", err)
+	}
+	// merged in status order, the pet listed under two statuses is kept once
+	if ids := petIds(pets); fmt.Sprint(ids) != "[1 2 3 4]" || len(queries) != 3 {
+		t.Fatalf("TestFindAllFansOut - unexpected pets %v after %d calls", ids, len(queries))
+	}
+
+	_, err = client.Pet.FindByStatuses(pet.FindByStatusRequest{
+		Statuses: []types.PetFindByStatusStatusEnum{types.PetFindByStatusStatusEnumSold, "broken"},
+	}, pet.FindByStatusesOptions{FanOut: true})
+	var apiErr sdkcore.ApiError
+	if !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "finding broken pets") {
+		t.Fatalf("TestFindAllFansOut - expected failing status to fail the query, got %v", err)
+	}
+}
+
+func TestFindByStatusesCancelsFanOutOnError(t *testing.T) {
+	cancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("status") == "broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		// the other call only ends once cancelled
+		select {
+		case <-r.Context().Done():
+			close(cancelled)
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL))
+
+	// a context of the caller's still applies to the calls
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := client.Pet.FindByStatuses(pet.FindByStatusRequest{
+		Statuses: []types.PetFindByStatusStatusEnum{types.PetFindByStatusStatusEnumSold, "broken"},
+	}, pet.FindByStatusesOptions{FanOut: true}, sdkcore.WithContext(ctx))
+	var apiErr sdkcore.ApiError
+	if !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "finding broken pets") {
+		t.Fatalf("TestFindByStatusesCancelsFanOutOnError - expected the failing call's error, got %v", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatalf("TestFindByStatusesCancelsFanOutOnError - the other call was not cancelled")
+	}
+}
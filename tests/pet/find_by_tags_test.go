@@ -0,0 +1,86 @@
+package test_pet_client
+
+import (
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	pet "pets_go/resources/pet"
+	types "pets_go/types"
+	testing "testing"
+)
+
+func TestFindByTags(t *testing.T) {
+	var query, apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, apiKey = r.URL.RawQuery, r.Header.Get("api_key")
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"id":1,"name":"Rex","photoUrls":[],"tags":[{"name":"tag1"}]}]`)
+	}))
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithApiKey("secret"))
+
+	pets, err := client.Pet.FindByTags(pet.FindByTagsRequest{Tags: []string{"tag1", "tag 2"}})
+	if err != nil {
+		t.Fatalf("TestFindByTags - failed with error: %#v", err)
+	}
+	if len(pets) != 1 || pets[0].Name != "Rex" || query != "tags=tag1&tags=tag%202" || apiKey != "secret" {
+		t.Fatalf("TestFindByTags - unexpected pets %+v for query %s", pets, query)
+	}
+
+	if _, err := client.Pet.FindByTags(pet.FindByTagsRequest{Tags: []string{"tag1", "tag2"}, TagsEncoding: sdkcore.ArrayEncodingComma}); err != nil || query != "tags=tag1%2Ctag2" {
+		t.Fatalf("TestFindByTags - unexpected comma query %s (%v)", query, err)
+	}
+	if _, err := client.Pet.FindByTags(pet.FindByTagsRequest{}); err != nil || query != "" {
+		t.Fatalf("TestFindByTags - unexpected query without tags %s (%v)", query, err)
+	}
+}
+
+func TestUpdateWithForm(t *testing.T) {
+	var method, path, query string
+	modified := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.RawQuery
+		if r.URL.Query().Get("name") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprintf(w, `<pet><id>7</id><name>%s</name><status>%s</status></pet>`, r.URL.Query().Get("name"), r.URL.Query().Get("status"))
+	}))
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithModifiers(func(req *http.Request) error {
+		modified = true
+		return nil
+	}))
+
+	updated, err := client.Pet.UpdateWithForm(pet.UpdateWithFormRequest{
+		PetId:  7,
+		Name:   nullable.NewValue("Rex"),
+		Status: nullable.NewValue(types.PetStatusEnumSold),
+	})
+	if err != nil {
+		t.Fatalf("TestUpdateWithForm - failed with error: %#v", err)
+	}
+	if method != "POST" || path != "/pet/7" || query != "name=Rex&status=sold" || !modified {
+		t.Fatalf("TestUpdateWithForm - unexpected request %s %s?%s", method, path, query)
+	}
+	if updated.Id.OrZero() != 7 || updated.Name != "Rex" || updated.Status.OrZero() != types.PetStatusEnumSold {
+		t.Fatalf("TestUpdateWithForm - unexpected pet %+v", updated)
+	}
+
+	var apiErr sdkcore.ApiError
+	if _, err := client.Pet.UpdateWithForm(pet.UpdateWithFormRequest{PetId: 7, Name: nullable.NewValue("missing")}); !errors.As(err, &apiErr) {
+		t.Fatalf("TestUpdateWithForm - expected api error, got %#v", err)
+	}
+
+	validating := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithRequestValidation())
+	var validationErr sdkcore.ValidationError
+	if _, err := validating.Pet.UpdateWithForm(pet.UpdateWithFormRequest{PetId: -1, Status: nullable.NewValue[types.PetStatusEnum]("adopted")}); !errors.As(err, &validationErr) || len(validationErr.Errors) != 2 {
+		t.Fatalf("TestUpdateWithForm - expected validation error, got %#v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package test_pet_client
+
+import (
+	bytes "bytes"
+	binary "encoding/binary"
+	errors "errors"
+	crc32 "hash/crc32"
+	image "image"
+	color "image/color"
+	png "image/png"
+	fs "io/fs"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	pet "pets_go/resources/pet"
+	testing "testing"
+	fstest "testing/fstest"
+)
+
+func newTestPNG(width int, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	buf := &bytes.Buffer{}
+	png.Encode(buf, img)
+	return buf.Bytes()
+}
+
+func TestPrepareImageDownscalesAndReencodes(t *testing.T) {
+	prepared, err := pet.PrepareImage(sdkcore.NewFileFromBytes("dog.png", newTestPNG(400, 200)), pet.ImageOptions{
+		MaxWidth:  100,
+		MaxHeight: 100,
+		Downscale: true,
+		Format:    pet.ImageFormatJPEG,
+	})
+	if err != nil {
+		t.Fatalf("TestPrepareImageDownscalesAndReencodes - failed preparing image with error: %#v", err)
+	}
+
+	content, _ := prepared.Open()
+	defer content.Close()
+	config, format, err := image.DecodeConfig(content)
+	if err != nil || format != "jpeg" || config.Width != 100 || config.Height != 50 {
+		t.Fatalf("TestPrepareImageDownscalesAndReencodes - unexpected %s image %dx%d (%v)", format, config.Width, config.Height, err)
+	}
+	if prepared.Filename != "dog.jpeg" || prepared.ContentType != "image/jpeg" {
+		t.Fatalf("TestPrepareImageDownscalesAndReencodes - unexpected file %s of type %s", prepared.Filename, prepared.ContentType)
+	}
+}
+
+func TestUploadImageRejectsInvalidImagesBeforeSending(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL))
+	_, err := client.Pet.UploadImage(pet.UploadImageRequest{
+		Data:         sdkcore.NewFileFromBytes("dog.png", newTestPNG(400, 200)),
+		PetId:        123,
+		ImageOptions: &pet.ImageOptions{MaxWidth: 100, AllowedFormats: []string{pet.ImageFormatPNG}},
+	})
+
+	var validationErr pet.ImageValidationError
+	if !errors.As(err, &validationErr) || validationErr.Kind != pet.ImageErrorDimensions {
+		t.Fatalf("TestUploadImageRejectsInvalidImagesBeforeSending - expected dimensions error, got %#v", err)
+	}
+	if requests != 0 {
+		t.Fatalf("TestUploadImageRejectsInvalidImagesBeforeSending - %d requests reached the server", requests)
+	}
+}
+
+// Tracks the files opened & closed
+type trackingFS struct {
+	fstest.MapFS
+	opened *int
+	closed *int
+}
+
+func (f trackingFS) Open(name string) (fs.File, error) {
+	file, err := f.MapFS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	*f.opened++
+	return trackedFile{File: file, closed: f.closed}, nil
+}
+
+type trackedFile struct {
+	fs.File
+	closed *int
+}
+
+func (f trackedFile) Close() error {
+	*f.closed++
+	return f.File.Close()
+}
+
+func TestUploadImageClosesFileWhenModifiersFail(t *testing.T) {
+	opened, closed := 0, 0
+	fsys := trackingFS{MapFS: fstest.MapFS{"dog.png": &fstest.MapFile{Data: newTestPNG(10, 10)}}, opened: &opened, closed: &closed}
+	data, err := sdkcore.NewFileFromFS(fsys, "dog.png")
+	if err != nil {
+		t.Fatalf("TestUploadImageClosesFileWhenModifiersFail - failed opening file with error: %#v", err)
+	}
+
+	client := sdk.NewClient(sdk.WithBaseURL("http://127.0.0.1:0"))
+	failing := errors.New("modifier failed")
+	_, err = client.Pet.UploadImage(pet.UploadImageRequest{Data: data, PetId: 123}, func(req *http.Request) error {
+		return failing
+	})
+	if !errors.Is(err, failing) {
+		t.Fatalf("TestUploadImageClosesFileWhenModifiersFail - expected modifier error, got %#v", err)
+	}
+	if opened != 1 || closed != 1 {
+		t.Fatalf("TestUploadImageClosesFileWhenModifiersFail - opened %d files, closed %d", opened, closed)
+	}
+}
+
+// A PNG header declaring the dimensions, with no image data
+func newPNGHeader(width uint32, height uint32) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], width)
+	binary.BigEndian.PutUint32(ihdr[8:], height)
+	ihdr[12], ihdr[13] = 8, 6 // 8 bit RGBA
+
+	data := append([]byte("\x89PNG\r\n\x1a\n"), 0, 0, 0, 13)
+	data = append(data, ihdr...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestPrepareImageCapsDecodedPixels(t *testing.T) {
+	// a few bytes declaring 10 gigapixels are rejected rather than decoded
+	_, err := pet.PrepareImage(sdkcore.NewFileFromBytes("bomb.png", newPNGHeader(100000, 100000)), pet.ImageOptions{
+		MaxWidth:  100,
+		Downscale: true,
+	})
+	var validationErr pet.ImageValidationError
+	if !errors.As(err, &validationErr) || validationErr.Kind != pet.ImageErrorDimensions || validationErr.Width != 100000 {
+		t.Fatalf("TestPrepareImageCapsDecodedPixels - expected dimensions error, got %#v", err)
+	}
+
+	_, err = pet.PrepareImage(sdkcore.NewFileFromBytes("dog.png", newTestPNG(400, 200)), pet.ImageOptions{
+		StripMetadata: true,
+		MaxPixels:     400*200 - 1,
+	})
+	if !errors.As(err, &validationErr) || validationErr.Kind != pet.ImageErrorDimensions {
+		t.Fatalf("TestPrepareImageCapsDecodedPixels - expected dimensions error, got %#v", err)
+	}
+	if _, err := pet.PrepareImage(sdkcore.NewFileFromBytes("dog.png", newTestPNG(400, 200)), pet.ImageOptions{StripMetadata: true, MaxPixels: 400 * 200}); err != nil {
+		t.Fatalf("TestPrepareImageCapsDecodedPixels - failed preparing image with error: %#v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package test_pet_client
+
+import (
+	json "encoding/json"
+	errors "errors"
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	nullable "pets_go/nullable"
+	pet "pets_go/resources/pet"
+	types "pets_go/types"
+	testing "testing"
+)
+
+// Serves GET /pet/{id} from the stored pet and replaces it on PUT /pet. beforeGet is
+// called before every GET, allowing concurrent writers to be simulated
+func newPetServer(stored *types.Pet, puts *int, beforeGet func()) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			if beforeGet != nil {
+				beforeGet()
+			}
+			json.NewEncoder(w).Encode(stored)
+		case "PUT":
+			*puts++
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, stored)
+		}
+	}))
+}
+
+func TestPatchUpdatesChangedFields(t *testing.T) {
+	stored := types.Pet{Id: nullable.NewValue(1), Name: "doggie", PhotoUrls: []string{"a"}, Status: nullable.NewValue(types.PetStatusEnumAvailable)}
+	puts := 0
+	server := newPetServer(&stored, &puts, nil)
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL))
+
+	res, err := client.Pet.Patch(pet.PatchRequest{
+		PetId: 1,
+		Patch: types.PetPatch{Status: nullable.NewValue(types.PetStatusEnumSold), Name: nullable.NewValue("doggie")},
+	})
+	if err != nil {
+		t.Fatalf("TestPatchUpdatesChangedFields - failed with error: %#v", err)
+	}
+	if len(res.ChangedFields) != 1 || res.ChangedFields[0] != "status" || puts != 1 {
+		t.Fatalf("TestPatchUpdatesChangedFields - unexpected changes %#v after %d puts", res.ChangedFields, puts)
+	}
+	if stored.Status.OrZero() != types.PetStatusEnumSold || stored.Name != "doggie" || len(stored.PhotoUrls) != 1 {
+		t.Fatalf("TestPatchUpdatesChangedFields - unexpected stored pet %+v", stored)
+	}
+
+	// a patch that changes nothing is not written
+	res, err = client.Pet.Patch(pet.PatchRequest{PetId: 1, Patch: types.PetPatch{Status: nullable.NewValue(types.PetStatusEnumSold)}})
+	if err != nil || len(res.ChangedFields) != 0 || puts != 1 {
+		t.Fatalf("TestPatchUpdatesChangedFields - expected no-op patch, got %#v %v after %d puts", res.ChangedFields, err, puts)
+	}
+}
+
+func TestPatchDetectsConflicts(t *testing.T) {
+	stored := types.Pet{Id: nullable.NewValue(1), Name: "doggie", PhotoUrls: []string{}}
+	puts, gets := 0, 0
+	// another writer renames the pet between every read
+	server := newPetServer(&stored, &puts, func() {
+		gets++
+		stored.Name = "doggie-" + string(rune('a'+gets))
+	})
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL))
+
+	_, err := client.Pet.Patch(pet.PatchRequest{
+		PetId:              1,
+		Patch:              types.PetPatch{Status: nullable.NewValue(types.PetStatusEnumPending)},
+		CheckConflicts:     true,
+		MaxConflictRetries: 2,
+	})
+
+	var conflictErr pet.PatchConflictError
+	if !errors.As(err, &conflictErr) || conflictErr.Attempts != 3 || puts != 0 {
+		t.Fatalf("TestPatchDetectsConflicts - expected conflict after 3 attempts, got %#v after %d puts", err, puts)
+	}
+}
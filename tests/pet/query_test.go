@@ -0,0 +1,89 @@
+package test_pet_client
+
+import (
+	json "encoding/json"
+	fmt "fmt"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	nullable "pets_go/nullable"
+	pet "pets_go/resources/pet"
+	types "pets_go/types"
+	regexp "regexp"
+	testing "testing"
+)
+
+func newQueryServer() *httptest.Server {
+	dogs := nullable.NewValue(types.Category{Id: nullable.NewValue(1), Name: nullable.NewValue("Dogs")})
+	cats := nullable.NewValue(types.Category{Id: nullable.NewValue(2), Name: nullable.NewValue("Cats")})
+	tags := func(names ...string) nullable.Nullable[[]types.Tag] {
+		list := []types.Tag{}
+		for _, name := range names {
+			list = append(list, types.Tag{Name: nullable.NewValue(name)})
+		}
+		return nullable.NewValue(list)
+	}
+	pets := []types.Pet{
+		{Id: nullable.NewValue(1), Name: "Rex", Category: dogs, Status: nullable.NewValue(types.PetStatusEnumPending), Tags: tags("vaccinated", "large"), PhotoUrls: []string{"a"}},
+		{Id: nullable.NewValue(2), Name: "Rover", Category: dogs, Status: nullable.NewValue(types.PetStatusEnumPending), Tags: tags("vaccinated"), PhotoUrls: []string{}},
+		{Id: nullable.NewValue(3), Name: "Buddy", Category: dogs, Status: nullable.NewValue(types.PetStatusEnumPending), Tags: tags("vaccinated"), PhotoUrls: []string{"b"}},
+		{Id: nullable.NewValue(4), Name: "Rufus", Category: dogs, Status: nullable.NewValue(types.PetStatusEnumAvailable), Tags: tags("vaccinated"), PhotoUrls: []string{}},
+		{Id: nullable.NewValue(5), Name: "Romeo", Category: cats, Status: nullable.NewValue(types.PetStatusEnumPending), Tags: tags("vaccinated"), PhotoUrls: []string{}},
+		{Id: nullable.NewValue(6), Name: "Ralph", Category: dogs, Status: nullable.NewValue(types.PetStatusEnumPending), Tags: tags("large"), PhotoUrls: []string{}},
+		{Id: nullable.NewValue(7), Name: "Ruby", Category: dogs, Status: nullable.NewValue(types.PetStatusEnumPending), Tags: tags("vaccinated", "small"), PhotoUrls: []string{}},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		statuses := map[string]bool{}
+		for _, status := range r.URL.Query()["status"] {
+			statuses[status] = true
+		}
+		matching := []types.Pet{}
+		for _, p := range pets {
+			if statuses[string(p.Status.OrZero())] {
+				matching = append(matching, p)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(matching)
+	}))
+}
+
+func queryIds(t *testing.T, query *pet.PetQuery) string {
+	pets, err := query.All()
+	if err != nil {
+		t.Fatalf("query failed with error: %#v", err)
+	}
+	return fmt.Sprint(petIds(pets))
+}
+
+func TestPetQuery(t *testing.T) {
+	server := newQueryServer()
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL))
+
+	// pending pets in category Dogs tagged vaccinated whose name starts with R
+	query := client.Pet.Query().Status(types.PetFindByStatusStatusEnumPending).CategoryName("Dogs").TaggedAll("vaccinated").NamePrefix("R")
+	if ids := queryIds(t, query); ids != "[1 2 7]" {
+		t.Fatalf("TestPetQuery - unexpected filtered pets %s", ids)
+	}
+
+	cases := map[string]*pet.PetQuery{
+		"[1 2 3 4 5 6 7]": client.Pet.Query(),
+		"[1 2 3 4 6 7]":   client.Pet.Query().CategoryID(1),
+		"[1 6 7]":         client.Pet.Query().TaggedAny("large", "small"),
+		"[1 3]":           client.Pet.Query().HasPhotos(true),
+		"[2 4 7]":         client.Pet.Query().HasPhotos(false).CategoryID(1).TaggedAll("vaccinated"),
+		"[3 6]":           client.Pet.Query().NameMatches(regexp.MustCompile(`^(B|Ra)`)),
+		"[4]":             client.Pet.Query().NameContains("UFU"),
+		"[2 3 4]":         client.Pet.Query().Offset(1).Limit(3),
+		"[3 6 1 5 2 7 4]": client.Pet.Query().SortBy(pet.ByName),
+		"[6 1 5]":         client.Pet.Query().SortBy(pet.ByName).Offset(1).Limit(3),
+		"[7 6 5]":         client.Pet.Query().SortBy(pet.Descending(pet.ByID)).Limit(3),
+		"[]":              client.Pet.Query().SortBy(pet.ByID).Offset(10),
+	}
+	for expected, query := range cases {
+		if ids := queryIds(t, query); ids != expected {
+			t.Fatalf("TestPetQuery - expected %s, got %s", expected, ids)
+		}
+	}
+}
@@ -59,6 +59,38 @@ func TestFindByStatus200SuccessRequiredOnly(t *testing.T) {
 	fmt.Printf("response - %#v\n", res)
 }
 
+func TestFindByTags200SuccessAllParams(t *testing.T) {
+	// Success test using all required and optional
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.Pet.FindByTags(pet.FindByTagsRequest{
+		Tags: []string{"string"},
+	})
+
+	if err != nil {
+		t.Fatalf("TestFindByTags200SuccessAllParams - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
+
+func TestFindByTags200SuccessRequiredOnly(t *testing.T) {
+	// Success test using only required fields
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.Pet.FindByTags(pet.FindByTagsRequest{})
+
+	if err != nil {
+		t.Fatalf("TestFindByTags200SuccessRequiredOnly - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
+
 func TestGet200SuccessAllParams(t *testing.T) {
 	// Success test using all required and optional
 	client := sdk.NewClient(
@@ -115,7 +147,7 @@ func TestUploadImage200SuccessAllParams(t *testing.T) {
 		sdk.WithEnv(sdk.MockServer),
 	)
 	res, err := client.Pet.UploadImage(pet.UploadImageRequest{
-		Data:               sdkcore.NewInMemoryFile("test.pdf", "123"),
+		Data:               sdkcore.NewFileFromBytes("test.pdf", []byte("123")),
 		PetId:              123,
 		AdditionalMetadata: nullable.NewValue("string"),
 	})
@@ -134,7 +166,7 @@ func TestUploadImage200SuccessRequiredOnly(t *testing.T) {
 		sdk.WithEnv(sdk.MockServer),
 	)
 	res, err := client.Pet.UploadImage(pet.UploadImageRequest{
-		Data:  sdkcore.NewInMemoryFile("test.pdf", "123"),
+		Data:  sdkcore.NewFileFromBytes("test.pdf", []byte("123")),
 		PetId: 123,
 	})
 
@@ -176,3 +208,39 @@ func TestUpdate200SuccessAllParams(t *testing.T) {
 
 	fmt.Printf("response - %#v\n", res)
 }
+
+func TestUpdateWithForm200SuccessAllParams(t *testing.T) {
+	// Success test using all required and optional
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.Pet.UpdateWithForm(pet.UpdateWithFormRequest{
+		PetId:  123,
+		Name:   nullable.NewValue("string"),
+		Status: nullable.NewValue(types.PetStatusEnumAvailable),
+	})
+
+	if err != nil {
+		t.Fatalf("TestUpdateWithForm200SuccessAllParams - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
+
+func TestUpdateWithForm200SuccessRequiredOnly(t *testing.T) {
+	// Success test using only required fields
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.Pet.UpdateWithForm(pet.UpdateWithFormRequest{
+		PetId: 123,
+	})
+
+	if err != nil {
+		t.Fatalf("TestUpdateWithForm200SuccessRequiredOnly - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
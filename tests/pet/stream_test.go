@@ -0,0 +1,148 @@
+package test_pet_client
+
+import (
+	context "context"
+	errors "errors"
+	fmt "fmt"
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	pet "pets_go/resources/pet"
+	types "pets_go/types"
+	testing "testing"
+	time "time"
+)
+
+// Streams count pets, pausing after the first batch until resume is closed so tests can
+// observe pets before the response is complete
+func newStreamingServer(count int, resume chan struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, "[")
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				io.WriteString(w, ",")
+			}
+			fmt.Fprintf(w, `{"id":%d,"name":"pet %d","photoUrls":[],"status":"available"}`, i, i)
+			if i == 100 {
+				w.(http.Flusher).Flush()
+				select {
+				case <-resume:
+				case <-r.Context().Done():
+					return
+				case <-time.After(5 * time.Second):
+					return
+				}
+			}
+		}
+		io.WriteString(w, "]")
+	}))
+}
+
+// Records whether response bodies get closed
+type closeTracker struct {
+	closed *bool
+}
+
+func (t closeTracker) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err == nil {
+		resp.Body = trackedBody{resp.Body, t.closed}
+	}
+	return resp, err
+}
+
+type trackedBody struct {
+	io.ReadCloser
+	closed *bool
+}
+
+func (b trackedBody) Close() error {
+	*b.closed = true
+	return b.ReadCloser.Close()
+}
+
+func TestFindByStatusIterStreams(t *testing.T) {
+	resume := make(chan struct{})
+	server := newStreamingServer(20000, resume)
+	defer server.Close()
+	closed := false
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithHTTPClient(&http.Client{Transport: closeTracker{&closed}}))
+
+	pets, err := client.Pet.FindByStatusIter(pet.FindByStatusRequest{})
+	if err != nil {
+		t.Fatalf("TestFindByStatusIterStreams - failed with error: %#v", err)
+	}
+	defer pets.Close()
+
+	count := 0
+	for pets.Next() {
+		if count == 0 {
+			// the server holds back the rest of the response until the first pet arrives
+			close(resume)
+		}
+		if id := pets.Pet().Id.OrZero(); id != count {
+			t.Fatalf("TestFindByStatusIterStreams - expected pet %d, got %d", count, id)
+		}
+		count++
+	}
+	if err := pets.Err(); err != nil || count != 20000 || !closed {
+		t.Fatalf("TestFindByStatusIterStreams - decoded %d pets, closed %v, error %v", count, closed, err)
+	}
+}
+
+func TestFindByStatusEachStopsEarly(t *testing.T) {
+	resume := make(chan struct{})
+	server := newStreamingServer(1000, resume)
+	defer server.Close()
+	closed := false
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithHTTPClient(&http.Client{Transport: closeTracker{&closed}}))
+
+	errStop := errors.New("stop")
+	seen := 0
+	err := client.Pet.FindByStatusEach(pet.FindByStatusRequest{}, func(p types.Pet) error {
+		seen++
+		if seen == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) || seen != 3 || !closed {
+		t.Fatalf("TestFindByStatusEachStopsEarly - saw %d pets, closed %v, error %v", seen, closed, err)
+	}
+
+	// cancelling the context stops the iteration while the server is still sending
+	ctx, cancel := context.WithCancel(context.Background())
+	seen = 0
+	err = client.Pet.FindByStatusEach(pet.FindByStatusRequest{}, func(p types.Pet) error {
+		seen++
+		if seen == 2 {
+			cancel()
+		}
+		return nil
+	}, sdkcore.WithContext(ctx))
+	if !errors.Is(err, context.Canceled) || seen != 2 {
+		t.Fatalf("TestFindByStatusEachStopsEarly - saw %d pets after cancel, error %v", seen, err)
+	}
+}
+
+func TestFindByStatusIterDecodingErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"name":"a","photoUrls":[],"status":"sold"},{"name":"b","photoUrls":[],"status":"adopted"}]`)
+	}))
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithEnumPolicy(sdkcore.EnumPolicyStrict))
+
+	names := []string{}
+	err := client.Pet.FindByStatusEach(pet.FindByStatusRequest{}, func(p types.Pet) error {
+		names = append(names, p.Name)
+		return nil
+	})
+	var enumErr sdkcore.UnknownEnumError
+	if !errors.As(err, &enumErr) || enumErr.Path != "[1].status" || len(names) != 1 {
+		t.Fatalf("TestFindByStatusIterDecodingErrors - expected enum error after 1 pet, got %v after %v", err, names)
+	}
+}
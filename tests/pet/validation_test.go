@@ -0,0 +1,53 @@
+package test_pet_client
+
+import (
+	errors "errors"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	pet "pets_go/resources/pet"
+	types "pets_go/types"
+	reflect "reflect"
+	testing "testing"
+)
+
+func TestCreateValidationListsFailingFields(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithRequestValidation())
+	_, err := client.Pet.Create(pet.CreateRequest{
+		Id:        nullable.NewValue(-1),
+		PhotoUrls: []string{"https://example.com/dog.png", "not a url"},
+		Status:    nullable.NewValue(types.PetStatusEnum("adopted")),
+		Category:  nullable.NewValue(types.Category{Id: nullable.NewValue(-2)}),
+		Tags:      nullable.NewValue([]types.Tag{{Id: nullable.NewValue(1)}, {Id: nullable.NewValue(-3)}}),
+	})
+
+	var validationErr sdkcore.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("TestCreateValidationListsFailingFields - expected validation error, got %#v", err)
+	}
+	paths := []string{}
+	for _, fieldErr := range validationErr.Errors {
+		paths = append(paths, fieldErr.Path)
+	}
+	expected := []string{"name", "category.id", "id", "photoUrls[1]", "status", "tags[1].id"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("TestCreateValidationListsFailingFields - expected paths %v, got %v", expected, paths)
+	}
+	if requests != 0 {
+		t.Fatalf("TestCreateValidationListsFailingFields - %d requests reached the server", requests)
+	}
+
+	// validation is opt-in
+	unvalidated := sdk.NewClient(sdk.WithBaseURL(server.URL))
+	if _, err := unvalidated.Pet.Get(pet.GetRequest{PetId: -1}); err != nil || requests != 1 {
+		t.Fatalf("TestCreateValidationListsFailingFields - expected unvalidated request to be sent, got %#v", err)
+	}
+}
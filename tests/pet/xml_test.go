@@ -0,0 +1,82 @@
+package test_pet_client
+
+import (
+	xml "encoding/xml"
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	pet "pets_go/resources/pet"
+	types "pets_go/types"
+	testing "testing"
+)
+
+func TestPetXMLEncoding(t *testing.T) {
+	fixture := types.Pet{
+		Category:  nullable.NewValue(types.Category{Id: nullable.NewValue(1), Name: nullable.NewNull[string]()}),
+		Name:      "doggie",
+		PhotoUrls: []string{"a", "b"},
+		Tags:      nullable.NewValue([]types.Tag{{Id: nullable.NewValue(1)}, {Name: nullable.NewValue("good")}}),
+	}
+
+	data, err := xml.Marshal(fixture)
+	if err != nil {
+		t.Fatalf("TestPetXMLEncoding - failed marshaling with error: %#v", err)
+	}
+	// undefined fields are omitted, null is marked with xsi:nil
+	expected := `<pet><category><id>1</id><name xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></name></category>` +
+		`<name>doggie</name><photoUrls><photoUrl>a</photoUrl><photoUrl>b</photoUrl></photoUrls>` +
+		`<tags><tag><id>1</id></tag><tag><name>good</name></tag></tags></pet>`
+	if string(data) != expected {
+		t.Fatalf("TestPetXMLEncoding - expected %s, got %s", expected, data)
+	}
+
+	var decoded types.Pet
+	if err := xml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("TestPetXMLEncoding - failed unmarshaling with error: %#v", err)
+	}
+	if !decoded.Equal(fixture) {
+		t.Fatalf("TestPetXMLEncoding - round trip changed the pet: %+v", decoded)
+	}
+}
+
+func TestPetXMLRequestsAndResponses(t *testing.T) {
+	var received string
+	var receivedType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			body, _ := io.ReadAll(r.Body)
+			received, receivedType = string(body), r.Header.Get("Content-Type")
+		case "GET":
+			w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+			io.WriteString(w, `<pets><pet><id>1</id><name>a</name><status>sold</status></pet><pet><id>2</id><name>b</name></pet></pets>`)
+		}
+	}))
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL))
+
+	_, err := client.Pet.Create(pet.CreateRequest{Name: "doggie", PhotoUrls: []string{}, ContentType: sdkcore.ContentTypeXML})
+	if err != nil {
+		t.Fatalf("TestPetXMLRequestsAndResponses - create failed with error: %#v", err)
+	}
+	if receivedType != sdkcore.ContentTypeXML || received != `<pet><name>doggie</name><photoUrls></photoUrls></pet>` {
+		t.Fatalf("TestPetXMLRequestsAndResponses - unexpected %s body %s", receivedType, received)
+	}
+
+	res, err := client.Pet.FindByStatus(pet.FindByStatusRequest{})
+	if err != nil {
+		t.Fatalf("TestPetXMLRequestsAndResponses - find failed with error: %#v", err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	var pets []types.Pet
+	if err := sdkcore.NewCoreClient(sdkcore.DefaultBaseURL("")).DecodeBody(res.Header.Get("Content-Type"), body, &pets); err != nil {
+		t.Fatalf("TestPetXMLRequestsAndResponses - failed decoding with error: %#v", err)
+	}
+	if len(pets) != 2 || pets[0].Status.OrZero() != types.PetStatusEnumSold || pets[1].Id.OrZero() != 2 || !pets[1].Status.IsUndefined() {
+		t.Fatalf("TestPetXMLRequestsAndResponses - unexpected pets %+v", pets)
+	}
+}
@@ -0,0 +1,91 @@
+package test_store_client
+
+import (
+	errors "errors"
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	types "pets_go/types"
+	reflect "reflect"
+	testing "testing"
+)
+
+// Serves pets from /pet/findByStatus, and the inventory unless it is empty
+func newInventoryServer(inventory string, paths *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		switch {
+		case r.URL.Path == "/store/inventory" && inventory != "":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, inventory)
+		case r.URL.Path == "/pet/findByStatus":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `[{"name":"a","photoUrls":[],"status":"available"},{"name":"b","photoUrls":[],"status":"sold"},`+
+				`{"name":"c","photoUrls":[],"status":"available"},{"name":"d","photoUrls":[]}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestInventory(t *testing.T) {
+	paths := []string{}
+	server := newInventoryServer(`{"available":3,"sold":1,"reserved":2}`, &paths)
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithApiKey("secret"))
+
+	inventory, err := client.Store.Inventory()
+	if err != nil {
+		t.Fatalf("TestInventory - failed with error: %#v", err)
+	}
+	if inventory.Count(types.PetStatusEnumAvailable) != 3 || inventory.Count(types.PetStatusEnumPending) != 0 || inventory.Total() != 6 {
+		t.Fatalf("TestInventory - unexpected inventory %v", inventory)
+	}
+	expected := map[types.PetStatusEnum]int{"available": 3, "pending": 0, "sold": 1}
+	if byStatus := inventory.ByStatus(); !reflect.DeepEqual(byStatus, expected) {
+		t.Fatalf("TestInventory - expected %v, got %v", expected, byStatus)
+	}
+	if unknown := inventory.Unknown(); !reflect.DeepEqual(unknown, []string{"reserved"}) {
+		t.Fatalf("TestInventory - unexpected unknown statuses %v", unknown)
+	}
+	reconciled := inventory.Reconcile()
+	if len(reconciled) != 4 || reconciled["pending"] != 0 || len(inventory) != 3 {
+		t.Fatalf("TestInventory - unexpected reconciled inventory %v", reconciled)
+	}
+
+	// reconciled like the fallback's inventory
+	fetched, err := client.Store.InventoryOrFallback()
+	if err != nil || !reflect.DeepEqual(paths, []string{"/store/inventory", "/store/inventory"}) {
+		t.Fatalf("TestInventory - unexpected fallback to %v (%v)", paths, err)
+	}
+	if !reflect.DeepEqual(fetched, reconciled) {
+		t.Fatalf("TestInventory - expected %v, got %v", reconciled, fetched)
+	}
+}
+
+func TestInventoryFallback(t *testing.T) {
+	paths := []string{}
+	server := newInventoryServer("", &paths)
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithApiKey("secret"))
+
+	var apiErr sdkcore.ApiError
+	if _, err := client.Store.Inventory(); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("TestInventoryFallback - expected not found error, got %#v", err)
+	}
+
+	inventory, err := client.Store.InventoryOrFallback()
+	if err != nil {
+		t.Fatalf("TestInventoryFallback - failed with error: %#v", err)
+	}
+	// pets without a status are not counted
+	expected := types.Inventory{"available": 2, "pending": 0, "sold": 1}
+	if !reflect.DeepEqual(inventory, expected) {
+		t.Fatalf("TestInventoryFallback - expected %v, got %v", expected, inventory)
+	}
+	if !reflect.DeepEqual(paths, []string{"/store/inventory", "/store/inventory", "/pet/findByStatus"}) {
+		t.Fatalf("TestInventoryFallback - unexpected requests %v", paths)
+	}
+}
@@ -0,0 +1,98 @@
+package test_order_client
+
+import (
+	json "encoding/json"
+	xml "encoding/xml"
+	io "io"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	order "pets_go/resources/store/order"
+	testutil "pets_go/tests/testutil"
+	types "pets_go/types"
+	testing "testing"
+	time "time"
+)
+
+func TestOrderConversionsCoverEveryField(t *testing.T) {
+	fixture := types.Order{
+		Complete: nullable.NewValue(true),
+		Id:       nullable.NewValue(10),
+		PetId:    nullable.NewValue(198772),
+		Quantity: nullable.NewValue(7),
+		ShipDate: nullable.NewValue(types.NewDateTime(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC))),
+		Status:   nullable.NewValue(types.OrderStatusEnumApproved),
+
+		AdditionalProperties: map[string]json.RawMessage{"carrier": json.RawMessage(`"ups"`)},
+	}
+
+	testutil.AssertFullyPopulated(t, fixture)
+	testutil.AssertSameFields(t, types.Order{}, order.CreateRequest{}, "ContentType")
+
+	if roundTrip := order.CreateRequestFromOrder(fixture).ToOrder(); !roundTrip.Equal(fixture) {
+		t.Fatalf("TestOrderConversionsCoverEveryField - round trip lost fields: %+v", roundTrip)
+	}
+}
+
+func TestShipDateEncoding(t *testing.T) {
+	shipDate := types.NewDateTime(time.Date(2024, 5, 10, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60)))
+
+	// every accepted server variant decodes to the same instant
+	variants := []string{
+		`"2024-05-10T10:30:00Z"`,
+		`"2024-05-10T12:30:00+02:00"`,
+		`"2024-05-10T10:30:00.000+0000"`,
+		`"2024-05-10 10:30:00"`,
+		`"2024-05-10T10:30:00"`,
+		`1715337000000`,
+	}
+	for _, variant := range variants {
+		var decoded types.Order
+		if err := json.Unmarshal([]byte(`{"shipDate":`+variant+`}`), &decoded); err != nil {
+			t.Fatalf("TestShipDateEncoding - failed decoding %s with error: %v", variant, err)
+		}
+		if !decoded.ShipDate.OrZero().Equal(shipDate) {
+			t.Fatalf("TestShipDateEncoding - decoded %s as %s", variant, decoded.ShipDate.OrZero())
+		}
+	}
+
+	// null is a no-op, like for encoding/json's own types
+	decoded := shipDate
+	if err := json.Unmarshal([]byte(`null`), &decoded); err != nil || decoded != shipDate {
+		t.Fatalf("TestShipDateEncoding - decoding null gave %s, %v", decoded, err)
+	}
+	var ordered types.Order
+	if err := json.Unmarshal([]byte(`{"shipDate":null}`), &ordered); err != nil || !ordered.ShipDate.IsNull() {
+		t.Fatalf("TestShipDateEncoding - expected a null ship date, got %#v, %v", ordered.ShipDate, err)
+	}
+
+	body, err := sdkcore.FormUrlEncodedBody(order.CreateRequest{ShipDate: nullable.NewValue(shipDate)}.ToOrder(), map[string]string{}, map[string]bool{})
+	if err != nil {
+		t.Fatalf("TestShipDateEncoding - failed encoding form with error: %v", err)
+	}
+	form, _ := io.ReadAll(body)
+	if expected := "shipDate=2024-05-10T12%3A30%3A00%2B02%3A00"; string(form) != expected {
+		t.Fatalf("TestShipDateEncoding - expected form %s, got %s", expected, form)
+	}
+}
+
+func TestOrderXMLEncoding(t *testing.T) {
+	fixture := types.Order{
+		Id:       nullable.NewValue(10),
+		ShipDate: nullable.NewValue(types.NewDateTime(time.Date(2024, 5, 10, 10, 30, 0, 0, time.UTC))),
+		Status:   nullable.NewValue(types.OrderStatusEnumPlaced),
+	}
+	data, err := xml.Marshal(fixture)
+	if err != nil {
+		t.Fatalf("TestOrderXMLEncoding - failed marshaling with error: %#v", err)
+	}
+	if expected := `<order><id>10</id><shipDate>2024-05-10T10:30:00Z</shipDate><status>placed</status></order>`; string(data) != expected {
+		t.Fatalf("TestOrderXMLEncoding - expected %s, got %s", expected, data)
+	}
+
+	// responses are decoded according to their content type
+	client := sdkcore.NewCoreClient(sdkcore.DefaultBaseURL(""))
+	var decoded types.Order
+	if err := client.DecodeBody("text/xml; charset=utf-8", data, &decoded); err != nil || !decoded.Equal(fixture) {
+		t.Fatalf("TestOrderXMLEncoding - unexpected decoded order %+v (%v)", decoded, err)
+	}
+}
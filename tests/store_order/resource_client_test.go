@@ -7,6 +7,7 @@ import (
 	order "pets_go/resources/store/order"
 	types "pets_go/types"
 	testing "testing"
+	time "time"
 )
 
 func TestDelete200SuccessAllParams(t *testing.T) {
@@ -54,7 +55,7 @@ func TestCreate200SuccessAllParams(t *testing.T) {
 		Id:       nullable.NewValue(10),
 		PetId:    nullable.NewValue(198772),
 		Quantity: nullable.NewValue(7),
-		ShipDate: nullable.NewValue("1970-01-01T00:00:00"),
+		ShipDate: nullable.NewValue(types.NewDateTime(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC))),
 		Status:   nullable.NewValue(types.OrderStatusEnumApproved),
 	})
 
@@ -0,0 +1,51 @@
+package testutil
+
+import (
+	nullable "pets_go/nullable"
+	reflect "reflect"
+	testing "testing"
+)
+
+// Fails if a field of either struct has no counterpart of the same type in the other,
+// fields only the request has, such as its ContentType option, are named in requestOnly
+func AssertSameFields(t *testing.T, model interface{}, request interface{}, requestOnly ...string) {
+	t.Helper()
+	modelType, requestType := reflect.TypeOf(model), reflect.TypeOf(request)
+	for _, pair := range [][2]reflect.Type{{modelType, requestType}, {requestType, modelType}} {
+		for i := 0; i < pair[0].NumField(); i++ {
+			field := pair[0].Field(i)
+			if !field.IsExported() || pair[0] == requestType && isOneOf(field.Name, requestOnly) {
+				continue
+			}
+			counterpart, ok := pair[1].FieldByName(field.Name)
+			if !ok || counterpart.Type != field.Type {
+				t.Fatalf("%s.%s has no mapping in %s", pair[0], field.Name, pair[1])
+			}
+		}
+	}
+}
+
+func isOneOf(name string, names []string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Fails if any exported field of the fixture is undefined or zero, so that fixtures
+// are extended whenever the model gains a field
+func AssertFullyPopulated(t *testing.T, fixture interface{}) {
+	t.Helper()
+	val := reflect.ValueOf(fixture)
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		if !val.Type().Field(i).IsExported() {
+			continue
+		}
+		if nullableLike, ok := field.Interface().(nullable.NullableLike); ok && nullableLike.IsUndefined() || field.IsZero() {
+			t.Fatalf("fixture field %s.%s is not set", val.Type(), val.Type().Field(i).Name)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package test_user_client
+
+import (
+	fmt "fmt"
+	sdk "pets_go/client"
+	nullable "pets_go/nullable"
+	user "pets_go/resources/user"
+	types "pets_go/types"
+	testing "testing"
+)
+
+func TestDelete200SuccessAllParams(t *testing.T) {
+	// Success test using all required and optional
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.User.Delete(user.DeleteRequest{
+		Username: "string",
+	})
+
+	if err != nil {
+		t.Fatalf("TestDelete200SuccessAllParams - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
+
+func TestGet200SuccessAllParams(t *testing.T) {
+	// Success test using all required and optional
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.User.Get(user.GetRequest{
+		Username: "string",
+	})
+
+	if err != nil {
+		t.Fatalf("TestGet200SuccessAllParams - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
+
+func TestCreate200SuccessAllParams(t *testing.T) {
+	// Success test using all required and optional
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.User.Create(user.CreateRequest{
+		Email:      nullable.NewValue("john@email.com"),
+		FirstName:  nullable.NewValue("John"),
+		Id:         nullable.NewValue(10),
+		LastName:   nullable.NewValue("James"),
+		Password:   nullable.NewValue("12345"),
+		Phone:      nullable.NewValue("12345"),
+		Username:   nullable.NewValue("theUser"),
+		UserStatus: nullable.NewValue(1),
+	})
+
+	if err != nil {
+		t.Fatalf("TestCreate200SuccessAllParams - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
+
+func TestCreateWithList200SuccessAllParams(t *testing.T) {
+	// Success test using all required and optional
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.User.CreateWithList(user.CreateWithListRequest{
+		Data: []types.User{
+			{Username: nullable.NewValue("theUser")},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("TestCreateWithList200SuccessAllParams - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
+
+func TestUpdate200SuccessAllParams(t *testing.T) {
+	// Success test using all required and optional
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.User.Update(user.UpdateRequest{
+		CurrentUsername: "string",
+		Username:        nullable.NewValue("theUser"),
+	})
+
+	if err != nil {
+		t.Fatalf("TestUpdate200SuccessAllParams - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
+
+func TestLogin200SuccessAllParams(t *testing.T) {
+	// Success test using all required and optional
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.User.Login(user.LoginRequest{
+		Username: nullable.NewValue("string"),
+		Password: nullable.NewValue("string"),
+	})
+
+	if err != nil {
+		t.Fatalf("TestLogin200SuccessAllParams - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
+
+func TestLogout200SuccessAllParams(t *testing.T) {
+	// Success test using all required and optional
+	client := sdk.NewClient(
+		sdk.WithApiKey("API_KEY"),
+		sdk.WithEnv(sdk.MockServer),
+	)
+	res, err := client.User.Logout()
+
+	if err != nil {
+		t.Fatalf("TestLogout200SuccessAllParams - failed making request with error: %#v", err)
+	}
+
+	fmt.Printf("response - %#v\n", res)
+}
@@ -0,0 +1,200 @@
+package test_user_client
+
+import (
+	fmt "fmt"
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	user "pets_go/resources/user"
+	sync "sync"
+	testing "testing"
+	time "time"
+)
+
+type sessionServer struct {
+	*httptest.Server
+	mu sync.Mutex
+	// offset of the server's clock, sent in the Date header
+	skew    time.Duration
+	logins  int
+	tokens  []string
+	logouts []string
+}
+
+// Hands out tokens tok-1, tok-2... expiring after ttl, recording the api_key of other requests
+func newSessionServer(ttl time.Duration, expiresHeader func(time.Time) string) *sessionServer {
+	s := &sessionServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch r.URL.Path {
+		case "/user/login":
+			if r.URL.Query().Get("password") != "secret" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.logins++
+			now := time.Now().Add(s.skew)
+			w.Header().Set("Date", now.UTC().Format(http.TimeFormat))
+			w.Header().Set("X-Rate-Limit", "5")
+			w.Header().Set("X-Expires-After", expiresHeader(now.Add(ttl)))
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `"tok-%d"`, s.logins)
+		case "/user/logout":
+			s.logouts = append(s.logouts, r.Header.Get("api_key"))
+		default:
+			s.tokens = append(s.tokens, r.Header.Get("api_key"))
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"username":"theUser"}`)
+		}
+	}))
+	return s
+}
+
+func rfc3339(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func TestSession(t *testing.T) {
+	server := newSessionServer(time.Hour, rfc3339)
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithApiKey("api-key"))
+
+	login := user.LoginRequest{Username: nullable.NewValue("theUser"), Password: nullable.NewValue("secret")}
+	if _, err := client.User.NewSession(user.LoginRequest{Username: nullable.NewValue("theUser")}, user.SessionOptions{}); err == nil {
+		t.Fatalf("TestSession - expected failed login error")
+	}
+	session, err := client.User.NewSession(login, user.SessionOptions{})
+	if err != nil {
+		t.Fatalf("TestSession - login failed with error: %#v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.User.Get(user.GetRequest{Username: "theUser"}); err != nil {
+			t.Fatalf("TestSession - request failed with error: %#v", err)
+		}
+	}
+	if rateLimit, ok := session.RateLimit(); !ok || rateLimit != 5 {
+		t.Fatalf("TestSession - unexpected rate limit %d", rateLimit)
+	}
+	if remaining, ok := session.RemainingCalls(); !ok || remaining != 3 {
+		t.Fatalf("TestSession - unexpected remaining calls %d", remaining)
+	}
+	if expires, ok := session.ExpiresAt(); !ok || time.Until(expires) < 59*time.Minute {
+		t.Fatalf("TestSession - unexpected expiry %s", expires)
+	}
+
+	if err := session.Close(); err != nil {
+		t.Fatalf("TestSession - logout failed with error: %#v", err)
+	}
+	if err := session.Close(); err != nil {
+		t.Fatalf("TestSession - second close failed with error: %#v", err)
+	}
+	// the api key applies again once the session is closed
+	if _, err := client.User.Get(user.GetRequest{Username: "theUser"}); err != nil {
+		t.Fatalf("TestSession - request failed with error: %#v", err)
+	}
+
+	expectedTokens := []string{"tok-1", "tok-1", "api-key"}
+	if fmt.Sprint(server.tokens) != fmt.Sprint(expectedTokens) || fmt.Sprint(server.logouts) != "[tok-1]" || server.logins != 1 {
+		t.Fatalf("TestSession - unexpected tokens %v, logouts %v after %d logins", server.tokens, server.logouts, server.logins)
+	}
+}
+
+func TestSessionRenewsExpiringTokens(t *testing.T) {
+	// tokens expiring within the refresh margin are renewed before each request
+	server := newSessionServer(time.Minute, func(expires time.Time) string {
+		return expires.UTC().Format(time.UnixDate)
+	})
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL))
+
+	session, err := client.User.NewSession(
+		user.LoginRequest{Password: nullable.NewValue("secret")},
+		user.SessionOptions{RefreshBefore: 2 * time.Hour, RequestMutator: sdkcore.NewAuthKeyHeader("api_key", "")},
+	)
+	if err != nil {
+		t.Fatalf("TestSessionRenewsExpiringTokens - login failed with error: %#v", err)
+	}
+	defer session.Close()
+	if _, ok := session.ExpiresAt(); !ok {
+		t.Fatalf("TestSessionRenewsExpiringTokens - Java date expiry was not parsed")
+	}
+
+	// the margin is capped at half the lifetime, a fresh token is used as is
+	if _, err := client.User.Get(user.GetRequest{Username: "theUser"}); err != nil || server.logins != 1 {
+		t.Fatalf("TestSessionRenewsExpiringTokens - unexpected %d logins (%v)", server.logins, err)
+	}
+
+	// the lifetime is measured on the server's clock, whatever the local one says
+	skewed := newSessionServer(time.Hour, rfc3339)
+	skewed.skew = 3 * time.Hour
+	defer skewed.Close()
+	client = sdk.NewClient(sdk.WithBaseURL(skewed.URL))
+	session, err = client.User.NewSession(user.LoginRequest{Password: nullable.NewValue("secret")}, user.SessionOptions{})
+	if err != nil {
+		t.Fatalf("TestSessionRenewsExpiringTokens - login failed with error: %#v", err)
+	}
+	defer session.Close()
+	if expires, ok := session.ExpiresAt(); !ok || time.Until(expires) < 59*time.Minute || time.Until(expires) > 61*time.Minute {
+		t.Fatalf("TestSessionRenewsExpiringTokens - unexpected expiry %s of a token valid for an hour", expires)
+	}
+
+	// tokens already expired when handed out are used as is rather than logging in
+	// again for every request
+	expired := newSessionServer(-time.Minute, rfc3339)
+	defer expired.Close()
+	client = sdk.NewClient(sdk.WithBaseURL(expired.URL))
+	session, err = client.User.NewSession(user.LoginRequest{Password: nullable.NewValue("secret")}, user.SessionOptions{})
+	if err != nil {
+		t.Fatalf("TestSessionRenewsExpiringTokens - login failed with error: %#v", err)
+	}
+	defer session.Close()
+	if _, ok := session.ExpiresAt(); ok {
+		t.Fatalf("TestSessionRenewsExpiringTokens - expected the expiry of an expired token to be unknown")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.User.Get(user.GetRequest{Username: "theUser"}); err != nil {
+				t.Errorf("TestSessionRenewsExpiringTokens - request failed with error: %#v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if expired.logins != 1 || len(expired.tokens) != 10 {
+		t.Fatalf("TestSessionRenewsExpiringTokens - unexpected %d logins for %d requests", expired.logins, len(expired.tokens))
+	}
+	for _, token := range expired.tokens {
+		if token != "tok-1" {
+			t.Fatalf("TestSessionRenewsExpiringTokens - unexpected token %s", token)
+		}
+	}
+
+	// a refresh still logs in again
+	if err := session.Refresh(); err != nil || expired.logins != 2 || session.Token() != "tok-2" {
+		t.Fatalf("TestSessionRenewsExpiringTokens - unexpected refresh to %s after %d logins (%v)", session.Token(), expired.logins, err)
+	}
+}
+
+func TestLoginPlainTextToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("X-Rate-Limit", "not a number")
+		io.WriteString(w, "logged in user session:1234")
+	}))
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL))
+
+	res, err := client.User.Login(user.LoginRequest{Username: nullable.NewValue("theUser"), Password: nullable.NewValue("secret")})
+	if err != nil || res.Token != "logged in user session:1234" || !res.RateLimit.IsZero() || !res.ExpiresAfter.IsZero() {
+		t.Fatalf("TestLoginPlainTextToken - unexpected response %+v (%v)", res, err)
+	}
+}
@@ -0,0 +1,142 @@
+package test_user_client
+
+import (
+	json "encoding/json"
+	xml "encoding/xml"
+	errors "errors"
+	io "io"
+	http "net/http"
+	httptest "net/http/httptest"
+	sdk "pets_go/client"
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+	user "pets_go/resources/user"
+	testutil "pets_go/tests/testutil"
+	types "pets_go/types"
+	reflect "reflect"
+	testing "testing"
+)
+
+type recordedRequest struct {
+	method      string
+	path        string
+	contentType string
+	body        string
+}
+
+// Records every request, answering with the user body
+func newUserServer(requests *[]recordedRequest, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*requests = append(*requests, recordedRequest{r.Method, r.URL.EscapedPath(), r.Header.Get("Content-Type"), string(body)})
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, response)
+	}))
+}
+
+func TestUserOperations(t *testing.T) {
+	requests := []recordedRequest{}
+	server := newUserServer(&requests, `{"id":10,"username":"theUser","userStatus":1,"nickname":"tu"}`)
+	defer server.Close()
+	client := sdk.NewClient(sdk.WithBaseURL(server.URL), sdk.WithRequestValidation())
+
+	expected := types.User{
+		Id:         nullable.NewValue(10),
+		Username:   nullable.NewValue("theUser"),
+		UserStatus: nullable.NewValue(1),
+
+		AdditionalProperties: map[string]json.RawMessage{"nickname": json.RawMessage(`"tu"`)},
+	}
+	got, err := client.User.Get(user.GetRequest{Username: "the user/1"})
+	if err != nil || !got.Equal(expected) {
+		t.Fatalf("TestUserOperations - unexpected user %+v (%v)", got, err)
+	}
+
+	created, err := client.User.Create(user.CreateRequest{Username: nullable.NewValue("theUser"), Email: nullable.NewNull[string]()})
+	if err != nil || !created.Equal(expected) {
+		t.Fatalf("TestUserOperations - unexpected created user %+v (%v)", created, err)
+	}
+	if _, err := client.User.CreateWithList(user.CreateWithListRequest{Data: []types.User{{Username: nullable.NewValue("a")}, {Username: nullable.NewValue("b")}}}); err != nil {
+		t.Fatalf("TestUserOperations - create with list failed with error: %#v", err)
+	}
+	if _, err := client.User.CreateWithList(user.CreateWithListRequest{Data: []types.User{{Username: nullable.NewValue("a")}}, ContentType: sdkcore.ContentTypeXML}); err != nil {
+		t.Fatalf("TestUserOperations - create with list as XML failed with error: %#v", err)
+	}
+	if _, err := client.User.Update(user.UpdateRequest{CurrentUsername: "theUser", Phone: nullable.NewValue("12345"), ContentType: sdkcore.ContentTypeFormUrlEncoded}); err != nil {
+		t.Fatalf("TestUserOperations - update failed with error: %#v", err)
+	}
+	if _, err := client.User.Delete(user.DeleteRequest{Username: "theUser"}); err != nil {
+		t.Fatalf("TestUserOperations - delete failed with error: %#v", err)
+	}
+
+	expectedRequests := []recordedRequest{
+		// usernames are escaped as a single path segment
+		{"GET", "/user/the%20user%2F1", "", ""},
+		{"POST", "/user", "application/json", `{"email":null,"username":"theUser"}`},
+		{"POST", "/user/createWithList", "application/json", `[{"username":"a"},{"username":"b"}]`},
+		{"POST", "/user/createWithList", "application/xml", `<user><username>a</username></user>`},
+		{"PUT", "/user/theUser", "application/x-www-form-urlencoded", "phone=12345"},
+		{"DELETE", "/user/theUser", "", ""},
+	}
+	if !reflect.DeepEqual(requests, expectedRequests) {
+		t.Fatalf("TestUserOperations - expected requests %+v, got %+v", expectedRequests, requests)
+	}
+
+	var validationErr sdkcore.ValidationError
+	if _, err := client.User.Get(user.GetRequest{}); !errors.As(err, &validationErr) || validationErr.Errors[0].Path != "username" {
+		t.Fatalf("TestUserOperations - expected validation error, got %#v", err)
+	}
+	if _, err := client.User.CreateWithList(user.CreateWithListRequest{Data: []types.User{{}, {Id: nullable.NewValue(-1)}}}); !errors.As(err, &validationErr) ||
+		validationErr.Errors[0].Path != "data[1].id" {
+		t.Fatalf("TestUserOperations - expected validation error, got %#v", err)
+	}
+}
+
+func TestUserConversionsCoverEveryField(t *testing.T) {
+	fixture := types.User{
+		Email:      nullable.NewValue("john@email.com"),
+		FirstName:  nullable.NewValue("John"),
+		Id:         nullable.NewValue(10),
+		LastName:   nullable.NewValue("James"),
+		Password:   nullable.NewValue("12345"),
+		Phone:      nullable.NewValue("12345"),
+		Username:   nullable.NewValue("theUser"),
+		UserStatus: nullable.NewValue(1),
+
+		AdditionalProperties: map[string]json.RawMessage{"nickname": json.RawMessage(`"tu"`)},
+	}
+
+	testutil.AssertFullyPopulated(t, fixture)
+
+	testutil.AssertSameFields(t, types.User{}, user.CreateRequest{}, "ContentType")
+	if roundTrip := user.CreateRequestFromUser(fixture).ToUser(); !roundTrip.Equal(fixture) {
+		t.Fatalf("TestUserConversionsCoverEveryField - CreateRequest round trip lost fields: %+v", roundTrip)
+	}
+
+	testutil.AssertSameFields(t, types.User{}, user.UpdateRequest{}, "CurrentUsername", "ContentType")
+	if request := user.UpdateRequestFromUser("oldName", fixture); request.CurrentUsername != "oldName" || !request.ToUser().Equal(fixture) {
+		t.Fatalf("TestUserConversionsCoverEveryField - UpdateRequest round trip lost fields: %+v", request)
+	}
+}
+
+func TestUserXMLEncoding(t *testing.T) {
+	fixture := types.User{
+		Id:       nullable.NewValue(10),
+		Username: nullable.NewValue("theUser"),
+		Phone:    nullable.NewNull[string](),
+	}
+	data, err := xml.Marshal(fixture)
+	if err != nil {
+		t.Fatalf("TestUserXMLEncoding - failed marshaling with error: %#v", err)
+	}
+	expected := `<user><id>10</id><phone xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></phone><username>theUser</username></user>`
+	if string(data) != expected {
+		t.Fatalf("TestUserXMLEncoding - expected %s, got %s", expected, data)
+	}
+
+	client := sdkcore.NewCoreClient(sdkcore.DefaultBaseURL(""))
+	var decoded types.User
+	if err := client.DecodeBody(sdkcore.ContentTypeXML, data, &decoded); err != nil || !decoded.Equal(fixture) {
+		t.Fatalf("TestUserXMLEncoding - unexpected decoded user %+v (%v)", decoded, err)
+	}
+}
@@ -1,12 +1,51 @@
 package types
 
 import (
+	json "encoding/json"
+	xml "encoding/xml"
 	nullable "pets_go/nullable"
 )
 
 // ApiResponse
 type ApiResponse struct {
-	Code    nullable.Nullable[int]    `json:"code,omitempty"`
-	Message nullable.Nullable[string] `json:"message,omitempty"`
-	Type    nullable.Nullable[string] `json:"type,omitempty"`
+	Code    nullable.Nullable[int]    `json:"code,omitempty" xml:"code,omitempty"`
+	Message nullable.Nullable[string] `json:"message,omitempty" xml:"message,omitempty"`
+	Type    nullable.Nullable[string] `json:"type,omitempty" xml:"type,omitempty"`
+	// Properties received that the SDK does not know about, re-encoded as is
+	AdditionalProperties map[string]json.RawMessage `json:"-" xml:"-"`
+}
+
+func (m ApiResponse) MarshalJSON() ([]byte, error) {
+	// omit undefined nullable fields
+	type alias ApiResponse
+	return nullable.MarshalStructWithAdditional(alias(m), m.AdditionalProperties)
+}
+
+func (m *ApiResponse) UnmarshalJSON(data []byte) error {
+	// capture unknown properties
+	type alias ApiResponse
+	additional, err := nullable.UnmarshalStruct(data, (*alias)(m))
+	if err != nil {
+		return err
+	}
+	m.AdditionalProperties = additional
+	return nil
+}
+
+func (m ApiResponse) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// omit undefined nullable fields
+	type alias ApiResponse
+	return nullable.MarshalXMLStruct(e, start, alias(m))
+}
+
+// Returns a deep copy sharing no slices, maps or pointers with the original
+func (m ApiResponse) Clone() ApiResponse {
+	type alias ApiResponse
+	return ApiResponse(nullable.DeepCopy(alias(m)))
+}
+
+// Reports whether both are deeply equal, nullable fields must be in the same state
+func (m ApiResponse) Equal(other ApiResponse) bool {
+	type alias ApiResponse
+	return nullable.DeepEqual(alias(m), alias(other))
 }
@@ -1,11 +1,53 @@
 package types
 
 import (
+	json "encoding/json"
+	xml "encoding/xml"
 	nullable "pets_go/nullable"
 )
 
 // Category
 type Category struct {
-	Id   nullable.Nullable[int]    `json:"id,omitempty"`
-	Name nullable.Nullable[string] `json:"name,omitempty"`
+	Id   nullable.Nullable[int]    `json:"id,omitempty" xml:"id,omitempty"`
+	Name nullable.Nullable[string] `json:"name,omitempty" xml:"name,omitempty"`
+	// Properties received that the SDK does not know about, re-encoded as is
+	AdditionalProperties map[string]json.RawMessage `json:"-" xml:"-"`
+}
+
+func (m Category) MarshalJSON() ([]byte, error) {
+	// omit undefined nullable fields
+	type alias Category
+	return nullable.MarshalStructWithAdditional(alias(m), m.AdditionalProperties)
+}
+
+func (m *Category) UnmarshalJSON(data []byte) error {
+	// capture unknown properties
+	type alias Category
+	additional, err := nullable.UnmarshalStruct(data, (*alias)(m))
+	if err != nil {
+		return err
+	}
+	m.AdditionalProperties = additional
+	return nil
+}
+
+func (m Category) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// omit undefined nullable fields, the element is named `category` unless its parent names it
+	type alias Category
+	if start.Name.Local == "Category" {
+		start.Name.Local = "category"
+	}
+	return nullable.MarshalXMLStruct(e, start, alias(m))
+}
+
+// Returns a deep copy sharing no slices, maps or pointers with the original
+func (m Category) Clone() Category {
+	type alias Category
+	return Category(nullable.DeepCopy(alias(m)))
+}
+
+// Reports whether both are deeply equal, nullable fields must be in the same state
+func (m Category) Equal(other Category) bool {
+	type alias Category
+	return nullable.DeepEqual(alias(m), alias(other))
 }
@@ -0,0 +1,124 @@
+package types
+
+import (
+	driver "database/sql/driver"
+	json "encoding/json"
+	fmt "fmt"
+	strconv "strconv"
+	strings "strings"
+	time "time"
+)
+
+// DateTime is an OpenAPI `date-time` value. It is encoded as RFC 3339 in JSON, form and
+// text encodings, and decodes RFC 3339 as well as the common variants servers send:
+// a space instead of `T`, offsets without a colon, no offset at all (read as UTC), a bare
+// date, and Unix epoch numbers in seconds or milliseconds
+type DateTime struct {
+	time.Time
+}
+
+// Layouts accepted when parsing, after RFC 3339
+var dateTimeLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// epoch numbers above this are read as milliseconds, it is in the year 5138 as seconds
+const epochMillisThreshold = 1e11
+
+// Constructor of a DateTime
+func NewDateTime(t time.Time) DateTime {
+	return DateTime{Time: t}
+}
+
+// Parses any of the formats DateTime accepts, values without an offset are read as UTC
+func ParseDateTime(value string) (DateTime, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return DateTime{Time: t}, nil
+	}
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return DateTime{Time: t}, nil
+		}
+	}
+	if epoch, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if epoch > epochMillisThreshold || epoch < -epochMillisThreshold {
+			return DateTime{Time: time.UnixMilli(epoch).UTC()}, nil
+		}
+		return DateTime{Time: time.Unix(epoch, 0).UTC()}, nil
+	}
+
+	return DateTime{}, fmt.Errorf("cannot parse %q as a date-time", value)
+}
+
+// Formats the value as RFC 3339, keeping its offset
+func (d DateTime) String() string {
+	return d.Time.Format(time.RFC3339Nano)
+}
+
+// Reports whether both values are the same instant, regardless of their location
+func (d DateTime) Equal(other DateTime) bool {
+	return d.Time.Equal(other.Time)
+}
+
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Decodes a date-time string or epoch number, `null` leaves the value unchanged like it
+// does for encoding/json's own types
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	if strings.TrimSpace(string(data)) == "null" {
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		// epoch numbers
+		value = string(data)
+	}
+
+	parsed, err := ParseDateTime(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d DateTime) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *DateTime) UnmarshalText(text []byte) error {
+	parsed, err := ParseDateTime(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Implements sql.Scanner for time and text columns
+func (d *DateTime) Scan(src interface{}) error {
+	switch srcVal := src.(type) {
+	case time.Time:
+		*d = DateTime{Time: srcVal}
+		return nil
+	case []byte:
+		return d.UnmarshalText(srcVal)
+	case string:
+		return d.UnmarshalText([]byte(srcVal))
+	}
+	return fmt.Errorf("cannot scan %T into DateTime", src)
+}
+
+// Implements driver.Valuer, written as a time.Time
+func (d DateTime) Value() (driver.Value, error) {
+	return d.Time, nil
+}
@@ -0,0 +1,13 @@
+package types
+
+import (
+	strings "strings"
+)
+
+func joinEnumValues[T ~string](values []T) string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = string(value)
+	}
+	return strings.Join(strs, ", ")
+}
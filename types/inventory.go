@@ -0,0 +1,57 @@
+package types
+
+import (
+	sort "sort"
+)
+
+// Pet counts keyed by status, as returned by GET /store/inventory. Servers may report
+// statuses beyond PetStatusEnum, these are kept and listed by Unknown
+type Inventory map[string]int
+
+// Returns the count of pets with the status, 0 if the inventory doesn't list it
+func (i Inventory) Count(status PetStatusEnum) int {
+	return i[string(status)]
+}
+
+// Returns the count of every PetStatusEnum value, those missing from the inventory as 0.
+// Statuses outside the enum are left out, see Unknown
+func (i Inventory) ByStatus() map[PetStatusEnum]int {
+	counts := map[PetStatusEnum]int{}
+	for _, status := range PetStatusEnumValues() {
+		counts[status] = i[string(status)]
+	}
+	return counts
+}
+
+// Returns the statuses of the inventory that aren't PetStatusEnum values, sorted
+func (i Inventory) Unknown() []string {
+	unknown := []string{}
+	for status := range i {
+		if !PetStatusEnum(status).IsValid() {
+			unknown = append(unknown, status)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// Returns the sum of every count, including those of unknown statuses
+func (i Inventory) Total() int {
+	total := 0
+	for _, count := range i {
+		total += count
+	}
+	return total
+}
+
+// Returns a copy of the inventory listing every PetStatusEnum value, missing ones as 0
+func (i Inventory) Reconcile() Inventory {
+	reconciled := Inventory{}
+	for status, count := range i {
+		reconciled[status] = count
+	}
+	for _, status := range PetStatusEnumValues() {
+		reconciled[string(status)] += 0
+	}
+	return reconciled
+}
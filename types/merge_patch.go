@@ -0,0 +1,126 @@
+package types
+
+import (
+	json "encoding/json"
+	nullable "pets_go/nullable"
+	reflect "reflect"
+	sort "sort"
+	strings "strings"
+)
+
+// PetPatch is a partial update of a Pet following JSON Merge Patch (RFC 7396) semantics:
+// undefined fields are left unchanged, null fields are removed and set fields replace the
+// current value. Category is merged field by field, PhotoUrls & Tags are replaced whole.
+// Its JSON encoding is the merge patch document
+type PetPatch struct {
+	Category  nullable.Nullable[Category]      `json:"category,omitempty"`
+	Id        nullable.Nullable[int]           `json:"id,omitempty"`
+	Name      nullable.Nullable[string]        `json:"name,omitempty"`
+	PhotoUrls nullable.Nullable[[]string]      `json:"photoUrls,omitempty"`
+	Status    nullable.Nullable[PetStatusEnum] `json:"status,omitempty"`
+	Tags      nullable.Nullable[[]Tag]         `json:"tags,omitempty"`
+}
+
+func (m PetPatch) MarshalJSON() ([]byte, error) {
+	// omit undefined nullable fields
+	type alias PetPatch
+	return nullable.MarshalStruct(alias(m))
+}
+
+// Computes the patch turning original into modified
+func DiffPet(original Pet, modified Pet) (PetPatch, error) {
+	var patch PetPatch
+	err := diffInto(original, modified, &patch)
+	return patch, err
+}
+
+// Returns a copy of the pet with the patch applied
+func (m PetPatch) Apply(pet Pet) (Pet, error) {
+	return applyPatch(pet, m)
+}
+
+// Reports whether the patch leaves every field unchanged
+func (m PetPatch) IsEmpty() bool {
+	return m.Equal(PetPatch{})
+}
+
+// Returns the JSON names of the fields the patch changes, in declaration order
+func (m PetPatch) Fields() []string {
+	return definedFields(m)
+}
+
+// Reports whether both patches are deeply equal
+func (m PetPatch) Equal(other PetPatch) bool {
+	type alias PetPatch
+	return nullable.DeepEqual(alias(m), alias(other))
+}
+
+// OrderPatch is a partial update of an Order following JSON Merge Patch (RFC 7396)
+// semantics, see PetPatch. Its JSON encoding is the merge patch document
+type OrderPatch Order
+
+func (m OrderPatch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Order(m))
+}
+
+// Computes the patch turning original into modified
+func DiffOrder(original Order, modified Order) (OrderPatch, error) {
+	var patch OrderPatch
+	err := diffInto(original, modified, (*Order)(&patch))
+	return patch, err
+}
+
+// Returns a copy of the order with the patch applied
+func (m OrderPatch) Apply(order Order) (Order, error) {
+	return applyPatch(order, m)
+}
+
+// Reports whether the patch leaves every field unchanged
+func (m OrderPatch) IsEmpty() bool {
+	return Order(m).Equal(Order{})
+}
+
+// Returns the JSON names of the fields the patch changes, in declaration order,
+// followed by the changed additional properties sorted by name
+func (m OrderPatch) Fields() []string {
+	fields := definedFields(m)
+	additional := make([]string, 0, len(m.AdditionalProperties))
+	for name := range m.AdditionalProperties {
+		additional = append(additional, name)
+	}
+	sort.Strings(additional)
+	return append(fields, additional...)
+}
+
+// Lists the JSON names of a struct's Nullable fields that are not undefined
+func definedFields(patch interface{}) []string {
+	fields := []string{}
+	val := reflect.ValueOf(patch)
+	for i := 0; i < val.NumField(); i++ {
+		nullableLike, ok := val.Field(i).Interface().(nullable.NullableLike)
+		if !ok || nullableLike.IsUndefined() {
+			continue
+		}
+		name, _, _ := strings.Cut(val.Type().Field(i).Tag.Get("json"), ",")
+		fields = append(fields, name)
+	}
+
+	return fields
+}
+
+func diffInto(original interface{}, modified interface{}, patch interface{}) error {
+	patchDoc, err := nullable.CreateMergePatch(original, modified)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(patchDoc, patch)
+}
+
+func applyPatch[T any](target T, patch interface{}) (T, error) {
+	patchDoc, err := json.Marshal(patch)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return nullable.MergePatchValue(target, patchDoc)
+}
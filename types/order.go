@@ -1,16 +1,58 @@
 package types
 
 import (
+	json "encoding/json"
+	xml "encoding/xml"
 	nullable "pets_go/nullable"
 )
 
 // Order
 type Order struct {
-	Complete nullable.Nullable[bool]   `json:"complete,omitempty"`
-	Id       nullable.Nullable[int]    `json:"id,omitempty"`
-	PetId    nullable.Nullable[int]    `json:"petId,omitempty"`
-	Quantity nullable.Nullable[int]    `json:"quantity,omitempty"`
-	ShipDate nullable.Nullable[string] `json:"shipDate,omitempty"`
+	Complete nullable.Nullable[bool]     `json:"complete,omitempty" xml:"complete,omitempty"`
+	Id       nullable.Nullable[int]      `json:"id,omitempty" xml:"id,omitempty"`
+	PetId    nullable.Nullable[int]      `json:"petId,omitempty" xml:"petId,omitempty"`
+	Quantity nullable.Nullable[int]      `json:"quantity,omitempty" xml:"quantity,omitempty"`
+	ShipDate nullable.Nullable[DateTime] `json:"shipDate,omitempty" xml:"shipDate,omitempty"`
 	// Order Status
-	Status nullable.Nullable[OrderStatusEnum] `json:"status,omitempty"`
+	Status nullable.Nullable[OrderStatusEnum] `json:"status,omitempty" xml:"status,omitempty"`
+	// Properties received that the SDK does not know about, re-encoded as is
+	AdditionalProperties map[string]json.RawMessage `json:"-" xml:"-"`
+}
+
+func (m Order) MarshalJSON() ([]byte, error) {
+	// omit undefined nullable fields
+	type alias Order
+	return nullable.MarshalStructWithAdditional(alias(m), m.AdditionalProperties)
+}
+
+func (m *Order) UnmarshalJSON(data []byte) error {
+	// capture unknown properties
+	type alias Order
+	additional, err := nullable.UnmarshalStruct(data, (*alias)(m))
+	if err != nil {
+		return err
+	}
+	m.AdditionalProperties = additional
+	return nil
+}
+
+func (m Order) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// omit undefined nullable fields, the element is named `order` unless its parent names it
+	type alias Order
+	if start.Name.Local == "Order" {
+		start.Name.Local = "order"
+	}
+	return nullable.MarshalXMLStruct(e, start, alias(m))
+}
+
+// Returns a deep copy sharing no slices, maps or pointers with the original
+func (m Order) Clone() Order {
+	type alias Order
+	return Order(nullable.DeepCopy(alias(m)))
+}
+
+// Reports whether both are deeply equal, nullable fields must be in the same state
+func (m Order) Equal(other Order) bool {
+	type alias Order
+	return nullable.DeepEqual(alias(m), alias(other))
 }
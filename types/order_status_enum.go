@@ -1,5 +1,9 @@
 package types
 
+import (
+	fmt "fmt"
+)
+
 // Order Status
 type OrderStatusEnum string
 
@@ -8,3 +12,31 @@ const (
 	OrderStatusEnumDelivered OrderStatusEnum = "delivered"
 	OrderStatusEnumPlaced    OrderStatusEnum = "placed"
 )
+
+// Returns every value of the enum, in declaration order
+func OrderStatusEnumValues() []OrderStatusEnum {
+	return []OrderStatusEnum{
+		OrderStatusEnumApproved,
+		OrderStatusEnumDelivered,
+		OrderStatusEnumPlaced,
+	}
+}
+
+// Parses a string into the enum, returning an error if it is not one of the enum's values
+func ParseOrderStatusEnum(value string) (OrderStatusEnum, error) {
+	e := OrderStatusEnum(value)
+	if !e.IsValid() {
+		return e, fmt.Errorf("%q is not a valid OrderStatusEnum, expected one of %s", value, joinEnumValues(OrderStatusEnumValues()))
+	}
+	return e, nil
+}
+
+// Reports whether the value is one of the enum's values. Unknown values, e.g. ones added
+// to the API after this SDK was generated, are kept when decoded leniently and report false
+func (e OrderStatusEnum) IsValid() bool {
+	switch e {
+	case OrderStatusEnumApproved, OrderStatusEnumDelivered, OrderStatusEnumPlaced:
+		return true
+	}
+	return false
+}
@@ -1,16 +1,58 @@
 package types
 
 import (
+	json "encoding/json"
+	xml "encoding/xml"
 	nullable "pets_go/nullable"
 )
 
 // Pet
 type Pet struct {
-	Category  nullable.Nullable[Category] `json:"category,omitempty"`
-	Id        nullable.Nullable[int]      `json:"id,omitempty"`
-	Name      string                      `json:"name"`
-	PhotoUrls []string                    `json:"photoUrls"`
+	Category  nullable.Nullable[Category] `json:"category,omitempty" xml:"category,omitempty"`
+	Id        nullable.Nullable[int]      `json:"id,omitempty" xml:"id,omitempty"`
+	Name      string                      `json:"name" xml:"name"`
+	PhotoUrls []string                    `json:"photoUrls" xml:"photoUrls>photoUrl"`
 	// pet status in the store
-	Status nullable.Nullable[PetStatusEnum] `json:"status,omitempty"`
-	Tags   nullable.Nullable[[]Tag]         `json:"tags,omitempty"`
+	Status nullable.Nullable[PetStatusEnum] `json:"status,omitempty" xml:"status,omitempty"`
+	Tags   nullable.Nullable[[]Tag]         `json:"tags,omitempty" xml:"tags>tag,omitempty"`
+	// Properties received that the SDK does not know about, re-encoded as is
+	AdditionalProperties map[string]json.RawMessage `json:"-" xml:"-"`
+}
+
+func (m Pet) MarshalJSON() ([]byte, error) {
+	// omit undefined nullable fields
+	type alias Pet
+	return nullable.MarshalStructWithAdditional(alias(m), m.AdditionalProperties)
+}
+
+func (m *Pet) UnmarshalJSON(data []byte) error {
+	// capture unknown properties
+	type alias Pet
+	additional, err := nullable.UnmarshalStruct(data, (*alias)(m))
+	if err != nil {
+		return err
+	}
+	m.AdditionalProperties = additional
+	return nil
+}
+
+func (m Pet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// omit undefined nullable fields, the element is named `pet` unless its parent names it
+	type alias Pet
+	if start.Name.Local == "Pet" {
+		start.Name.Local = "pet"
+	}
+	return nullable.MarshalXMLStruct(e, start, alias(m))
+}
+
+// Returns a deep copy sharing no slices, maps or pointers with the original
+func (m Pet) Clone() Pet {
+	type alias Pet
+	return Pet(nullable.DeepCopy(alias(m)))
+}
+
+// Reports whether both are deeply equal, nullable fields must be in the same state
+func (m Pet) Equal(other Pet) bool {
+	type alias Pet
+	return nullable.DeepEqual(alias(m), alias(other))
 }
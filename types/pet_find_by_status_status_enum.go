@@ -1,5 +1,9 @@
 package types
 
+import (
+	fmt "fmt"
+)
+
 // Status values that need to be considered for filter
 type PetFindByStatusStatusEnum string
 
@@ -8,3 +12,36 @@ const (
 	PetFindByStatusStatusEnumPending   PetFindByStatusStatusEnum = "pending"
 	PetFindByStatusStatusEnumSold      PetFindByStatusStatusEnum = "sold"
 )
+
+// Returns every value of the enum, in declaration order
+func PetFindByStatusStatusEnumValues() []PetFindByStatusStatusEnum {
+	return []PetFindByStatusStatusEnum{
+		PetFindByStatusStatusEnumAvailable,
+		PetFindByStatusStatusEnumPending,
+		PetFindByStatusStatusEnumSold,
+	}
+}
+
+// Parses a string into the enum, returning an error if it is not one of the enum's values
+func ParsePetFindByStatusStatusEnum(value string) (PetFindByStatusStatusEnum, error) {
+	e := PetFindByStatusStatusEnum(value)
+	if !e.IsValid() {
+		return e, fmt.Errorf("%q is not a valid PetFindByStatusStatusEnum, expected one of %s", value, joinEnumValues(PetFindByStatusStatusEnumValues()))
+	}
+	return e, nil
+}
+
+// Reports whether the value is one of the enum's values. Unknown values, e.g. ones added
+// to the API after this SDK was generated, are kept when decoded leniently and report false
+func (e PetFindByStatusStatusEnum) IsValid() bool {
+	switch e {
+	case PetFindByStatusStatusEnumAvailable, PetFindByStatusStatusEnumPending, PetFindByStatusStatusEnumSold:
+		return true
+	}
+	return false
+}
+
+// Converts the filter value into the equivalent pet status
+func (e PetFindByStatusStatusEnum) ToPetStatus() PetStatusEnum {
+	return PetStatusEnum(e)
+}
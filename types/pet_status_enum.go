@@ -1,5 +1,9 @@
 package types
 
+import (
+	fmt "fmt"
+)
+
 // pet status in the store
 type PetStatusEnum string
 
@@ -8,3 +12,36 @@ const (
 	PetStatusEnumPending   PetStatusEnum = "pending"
 	PetStatusEnumSold      PetStatusEnum = "sold"
 )
+
+// Returns every value of the enum, in declaration order
+func PetStatusEnumValues() []PetStatusEnum {
+	return []PetStatusEnum{
+		PetStatusEnumAvailable,
+		PetStatusEnumPending,
+		PetStatusEnumSold,
+	}
+}
+
+// Parses a string into the enum, returning an error if it is not one of the enum's values
+func ParsePetStatusEnum(value string) (PetStatusEnum, error) {
+	e := PetStatusEnum(value)
+	if !e.IsValid() {
+		return e, fmt.Errorf("%q is not a valid PetStatusEnum, expected one of %s", value, joinEnumValues(PetStatusEnumValues()))
+	}
+	return e, nil
+}
+
+// Reports whether the value is one of the enum's values. Unknown values, e.g. ones added
+// to the API after this SDK was generated, are kept when decoded leniently and report false
+func (e PetStatusEnum) IsValid() bool {
+	switch e {
+	case PetStatusEnumAvailable, PetStatusEnumPending, PetStatusEnumSold:
+		return true
+	}
+	return false
+}
+
+// Converts the status into the equivalent findByStatus filter value
+func (e PetStatusEnum) ToFindByStatus() PetFindByStatusStatusEnum {
+	return PetFindByStatusStatusEnum(e)
+}
@@ -1,11 +1,53 @@
 package types
 
 import (
+	json "encoding/json"
+	xml "encoding/xml"
 	nullable "pets_go/nullable"
 )
 
 // Tag
 type Tag struct {
-	Id   nullable.Nullable[int]    `json:"id,omitempty"`
-	Name nullable.Nullable[string] `json:"name,omitempty"`
+	Id   nullable.Nullable[int]    `json:"id,omitempty" xml:"id,omitempty"`
+	Name nullable.Nullable[string] `json:"name,omitempty" xml:"name,omitempty"`
+	// Properties received that the SDK does not know about, re-encoded as is
+	AdditionalProperties map[string]json.RawMessage `json:"-" xml:"-"`
+}
+
+func (m Tag) MarshalJSON() ([]byte, error) {
+	// omit undefined nullable fields
+	type alias Tag
+	return nullable.MarshalStructWithAdditional(alias(m), m.AdditionalProperties)
+}
+
+func (m *Tag) UnmarshalJSON(data []byte) error {
+	// capture unknown properties
+	type alias Tag
+	additional, err := nullable.UnmarshalStruct(data, (*alias)(m))
+	if err != nil {
+		return err
+	}
+	m.AdditionalProperties = additional
+	return nil
+}
+
+func (m Tag) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// omit undefined nullable fields, the element is named `tag` unless its parent names it
+	type alias Tag
+	if start.Name.Local == "Tag" {
+		start.Name.Local = "tag"
+	}
+	return nullable.MarshalXMLStruct(e, start, alias(m))
+}
+
+// Returns a deep copy sharing no slices, maps or pointers with the original
+func (m Tag) Clone() Tag {
+	type alias Tag
+	return Tag(nullable.DeepCopy(alias(m)))
+}
+
+// Reports whether both are deeply equal, nullable fields must be in the same state
+func (m Tag) Equal(other Tag) bool {
+	type alias Tag
+	return nullable.DeepEqual(alias(m), alias(other))
 }
@@ -0,0 +1,60 @@
+package types
+
+import (
+	json "encoding/json"
+	xml "encoding/xml"
+	nullable "pets_go/nullable"
+)
+
+// User
+type User struct {
+	Email     nullable.Nullable[string] `json:"email,omitempty" xml:"email,omitempty"`
+	FirstName nullable.Nullable[string] `json:"firstName,omitempty" xml:"firstName,omitempty"`
+	Id        nullable.Nullable[int]    `json:"id,omitempty" xml:"id,omitempty"`
+	LastName  nullable.Nullable[string] `json:"lastName,omitempty" xml:"lastName,omitempty"`
+	Password  nullable.Nullable[string] `json:"password,omitempty" xml:"password,omitempty"`
+	Phone     nullable.Nullable[string] `json:"phone,omitempty" xml:"phone,omitempty"`
+	Username  nullable.Nullable[string] `json:"username,omitempty" xml:"username,omitempty"`
+	// User Status
+	UserStatus nullable.Nullable[int] `json:"userStatus,omitempty" xml:"userStatus,omitempty"`
+	// Properties received that the SDK does not know about, re-encoded as is
+	AdditionalProperties map[string]json.RawMessage `json:"-" xml:"-"`
+}
+
+func (m User) MarshalJSON() ([]byte, error) {
+	// omit undefined nullable fields
+	type alias User
+	return nullable.MarshalStructWithAdditional(alias(m), m.AdditionalProperties)
+}
+
+func (m *User) UnmarshalJSON(data []byte) error {
+	// capture unknown properties
+	type alias User
+	additional, err := nullable.UnmarshalStruct(data, (*alias)(m))
+	if err != nil {
+		return err
+	}
+	m.AdditionalProperties = additional
+	return nil
+}
+
+func (m User) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// omit undefined nullable fields, the element is named `user` unless its parent names it
+	type alias User
+	if start.Name.Local == "User" {
+		start.Name.Local = "user"
+	}
+	return nullable.MarshalXMLStruct(e, start, alias(m))
+}
+
+// Returns a deep copy sharing no slices, maps or pointers with the original
+func (m User) Clone() User {
+	type alias User
+	return User(nullable.DeepCopy(alias(m)))
+}
+
+// Reports whether both are deeply equal, nullable fields must be in the same state
+func (m User) Equal(other User) bool {
+	type alias User
+	return nullable.DeepEqual(alias(m), alias(other))
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	sdkcore "pets_go/core"
+	nullable "pets_go/nullable"
+)
+
+// Checks the pet against the constraints of the API schema
+func (m Pet) Validate() error {
+	v := &sdkcore.Validator{}
+	m.ValidateAt(v, "")
+	return v.Err()
+}
+
+// Records the pet's field errors under the given JSON path
+func (m Pet) ValidateAt(v *sdkcore.Validator, path string) {
+	v.Check(m.Name != "", sdkcore.JoinPath(path, "name"), "is required")
+	v.Check(m.PhotoUrls != nil, sdkcore.JoinPath(path, "photoUrls"), "is required")
+	validatePetFields(v, path, m.Category, m.Id, m.PhotoUrls, m.Status, m.Tags)
+}
+
+// Checks the patch against the constraints of the API schema, undefined fields are not checked
+func (m PetPatch) Validate() error {
+	v := &sdkcore.Validator{}
+	m.ValidateAt(v, "")
+	return v.Err()
+}
+
+// Records the patch's field errors under the given JSON path
+func (m PetPatch) ValidateAt(v *sdkcore.Validator, path string) {
+	// required fields cannot be removed
+	v.Check(!m.Name.IsNull(), sdkcore.JoinPath(path, "name"), "is required")
+	v.Check(!m.PhotoUrls.IsNull(), sdkcore.JoinPath(path, "photoUrls"), "is required")
+	if name, err := m.Name.Value(); err == nil {
+		v.Check(name != "", sdkcore.JoinPath(path, "name"), "is required")
+	}
+	validatePetFields(v, path, m.Category, m.Id, m.PhotoUrls.OrZero(), m.Status, m.Tags)
+}
+
+func validatePetFields(
+	v *sdkcore.Validator,
+	path string,
+	category nullable.Nullable[Category],
+	id nullable.Nullable[int],
+	photoUrls []string,
+	status nullable.Nullable[PetStatusEnum],
+	tags nullable.Nullable[[]Tag],
+) {
+	if categoryVal, err := category.Value(); err == nil {
+		categoryVal.ValidateAt(v, sdkcore.JoinPath(path, "category"))
+	}
+	if idVal, err := id.Value(); err == nil {
+		v.NonNegative(idVal, sdkcore.JoinPath(path, "id"))
+	}
+	for i, photoUrl := range photoUrls {
+		v.AbsoluteURL(photoUrl, sdkcore.IndexPath(sdkcore.JoinPath(path, "photoUrls"), i))
+	}
+	if statusVal, err := status.Value(); err == nil {
+		v.Check(statusVal.IsValid(), sdkcore.JoinPath(path, "status"), "must be one of "+joinEnumValues(PetStatusEnumValues()))
+	}
+	if tagsVal, err := tags.Value(); err == nil {
+		for i, tag := range tagsVal {
+			tag.ValidateAt(v, sdkcore.IndexPath(sdkcore.JoinPath(path, "tags"), i))
+		}
+	}
+}
+
+// Records the category's field errors under the given JSON path
+func (m Category) ValidateAt(v *sdkcore.Validator, path string) {
+	if id, err := m.Id.Value(); err == nil {
+		v.NonNegative(id, sdkcore.JoinPath(path, "id"))
+	}
+}
+
+// Records the tag's field errors under the given JSON path
+func (m Tag) ValidateAt(v *sdkcore.Validator, path string) {
+	if id, err := m.Id.Value(); err == nil {
+		v.NonNegative(id, sdkcore.JoinPath(path, "id"))
+	}
+}
+
+// Checks the order against the constraints of the API schema
+func (m Order) Validate() error {
+	v := &sdkcore.Validator{}
+	m.ValidateAt(v, "")
+	return v.Err()
+}
+
+// Records the order's field errors under the given JSON path
+func (m Order) ValidateAt(v *sdkcore.Validator, path string) {
+	if id, err := m.Id.Value(); err == nil {
+		v.NonNegative(id, sdkcore.JoinPath(path, "id"))
+	}
+	if petId, err := m.PetId.Value(); err == nil {
+		v.NonNegative(petId, sdkcore.JoinPath(path, "petId"))
+	}
+	if quantity, err := m.Quantity.Value(); err == nil {
+		v.NonNegative(quantity, sdkcore.JoinPath(path, "quantity"))
+	}
+	if status, err := m.Status.Value(); err == nil {
+		v.Check(status.IsValid(), sdkcore.JoinPath(path, "status"), "must be one of "+joinEnumValues(OrderStatusEnumValues()))
+	}
+}
+
+// Checks the user against the constraints of the API schema
+func (m User) Validate() error {
+	v := &sdkcore.Validator{}
+	m.ValidateAt(v, "")
+	return v.Err()
+}
+
+// Records the user's field errors under the given JSON path
+func (m User) ValidateAt(v *sdkcore.Validator, path string) {
+	if id, err := m.Id.Value(); err == nil {
+		v.NonNegative(id, sdkcore.JoinPath(path, "id"))
+	}
+}